@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backoff - provides exponential backoff with jitter and retry budgets for failed requests
+package backoff
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrBudgetExhausted - returned when no more attempts are allowed
+var ErrBudgetExhausted = errors.New("retry budget exhausted")
+
+// Backoff - exponential backoff with jitter
+type Backoff struct {
+	// InitialDelay - delay before the first retry
+	InitialDelay time.Duration
+	// MaxDelay - upper bound for the delay, 0 means no bound
+	MaxDelay time.Duration
+	// Multiplier - factor the delay is multiplied by after each retry
+	Multiplier float64
+	// Jitter - randomization factor in [0, 1], the delay is randomly changed by up to Jitter * delay
+	Jitter float64
+
+	attempt int
+}
+
+// Next - returns the delay before the next retry
+func (b *Backoff) Next() time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(b.InitialDelay) * math.Pow(multiplier, float64(b.attempt))
+	maxDelay := float64(b.MaxDelay)
+	if maxDelay <= 0 {
+		maxDelay = math.MaxInt64 / 2
+	}
+	if delay >= maxDelay {
+		delay = maxDelay
+	} else {
+		b.attempt++
+	}
+	if jitter := math.Min(math.Max(b.Jitter, 0), 1); jitter > 0 {
+		// #nosec G404 - jitter doesn't need a cryptographically secure random
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// Reset - resets the delay to InitialDelay
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
+
+// Wait - waits for the next delay or until ctx is done
+func (b *Backoff) Wait(ctx context.Context) error {
+	timer := time.NewTimer(b.Next())
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Budget - limits a number of attempts, can be shared between goroutines
+type Budget struct {
+	max   int64
+	spent atomic.Int64
+}
+
+// NewBudget - creates a Budget allowing maxAttempts attempts, maxAttempts <= 0 means no limit
+func NewBudget(maxAttempts int) *Budget {
+	return &Budget{max: int64(maxAttempts)}
+}
+
+// Take - spends one attempt, returns ErrBudgetExhausted if there are no attempts left
+func (b *Budget) Take() error {
+	if b == nil || b.max <= 0 {
+		return nil
+	}
+	if b.spent.Add(1) > b.max {
+		return ErrBudgetExhausted
+	}
+	return nil
+}
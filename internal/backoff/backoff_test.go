@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+)
+
+func TestBackoff_Next(t *testing.T) {
+	b := &backoff.Backoff{
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     time.Second,
+		Multiplier:   2,
+	}
+
+	for _, expected := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		require.Equal(t, expected, b.Next())
+	}
+
+	b.Reset()
+	require.Equal(t, 100*time.Millisecond, b.Next())
+}
+
+func TestBackoff_Jitter(t *testing.T) {
+	b := &backoff.Backoff{
+		InitialDelay: time.Second,
+		MaxDelay:     time.Second,
+		Multiplier:   2,
+		Jitter:       0.5,
+	}
+
+	for i := 0; i < 100; i++ {
+		delay := b.Next()
+		require.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		require.LessOrEqual(t, delay, 1500*time.Millisecond)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	b := backoff.NewBudget(2)
+	require.NoError(t, b.Take())
+	require.NoError(t, b.Take())
+	require.ErrorIs(t, b.Take(), backoff.ErrBudgetExhausted)
+
+	unlimited := backoff.NewBudget(0)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, unlimited.Take())
+	}
+}
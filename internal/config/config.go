@@ -25,51 +25,213 @@ package config
 
 import (
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/kelseyhightower/envconfig"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/awarenessgroups"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/peerauth"
+)
+
+// Log formats
+const (
+	// LogFormatJSON - JSON log lines
+	LogFormatJSON = "json"
+	// LogFormatText - logfmt log lines
+	LogFormatText = "text"
+	// LogFormatNested - human readable log lines
+	LogFormatNested = "nested"
+)
+
+// Hook failure policies
+const (
+	// HooksFailurePolicyIgnore - the hook failure is logged
+	HooksFailurePolicyIgnore = "ignore"
+	// HooksFailurePolicyExit - the hook failure is logged and cmd-nsc exits
+	HooksFailurePolicyExit = "exit"
 )
 
 // Config - configuration for cmd-nsmgr
 type Config struct {
+	ConfigFile              string        `default:"" desc:"Path to YAML or JSON configuration file, environment variables override its values" split_words:"true"`
+	ConfigFileWatchInterval time.Duration `default:"0" desc:"Interval between checks of the configuration file changes, 0 disables the watch" split_words:"true"`
+
 	Name             string        `default:"nsc" desc:"Name of Network Service Client"`
-	ConnectTo        url.URL       `default:"unix:///var/lib/networkservicemesh/nsm.io.sock" desc:"url to connect to NSM" split_words:"true"`
+	ConnectTo        []url.URL     `default:"unix:///var/lib/networkservicemesh/nsm.io.sock" desc:"A list of NSMgr URLs to connect to, the next one is used if the current one is unreachable" split_words:"true"`
 	DialTimeout      time.Duration `default:"5s" desc:"timeout to dial NSMgr" split_words:"true"`
 	RequestTimeout   time.Duration `default:"15s" desc:"timeout to request NSE" split_words:"true"`
 	MaxTokenLifetime time.Duration `default:"10m" desc:"maximum lifetime of tokens" split_words:"true"`
+	AllowedServers   []string      `default:"" desc:"A list of allowed NSMgr SPIFFE IDs, trust domains or path patterns, empty allows any SPIFFE ID" split_words:"true"`
+
+	SVIDCertFile          string        `default:"" desc:"Path to PEM X.509 SVID certificates chain, empty means the SPIFFE Workload API is used" split_words:"true"`
+	SVIDKeyFile           string        `default:"" desc:"Path to PEM X.509 SVID private key" split_words:"true"`
+	SVIDBundleFile        string        `default:"" desc:"Path to PEM X.509 trust bundle" split_words:"true"`
+	SVIDFileWatchInterval time.Duration `default:"10s" desc:"Interval between checks of the SVID files changes" split_words:"true"`
+
+	RequestBackoffInitialDelay time.Duration `default:"100ms" desc:"Delay before the first retry of a failed request" split_words:"true"`
+	RequestBackoffMaxDelay     time.Duration `default:"5s" desc:"Maximum delay between retries of a failed request" split_words:"true"`
+	RequestBackoffMultiplier   float64       `default:"2" desc:"Factor the retry delay is multiplied by after each failed request" split_words:"true"`
+	RequestBackoffJitter       float64       `default:"0.2" desc:"Randomization factor of the retry delay in [0, 1]" split_words:"true"`
+	RequestMaxAttempts         int           `default:"0" desc:"Maximum number of request attempts per Network Service, 0 means no limit" split_words:"true"`
+	RequestMaxTotalAttempts    int           `default:"0" desc:"Maximum number of request attempts for all Network Services on start, reload or control API request, 0 means no limit" split_words:"true"`
 
 	Labels    []string `default:"" desc:"A list of client labels with format key1=val1,key2=val2, will be used a primary list for network services" split_words:"true"`
 	Mechanism string   `default:"kernel" desc:"Default Mechanism to use, supported values: kernel, vfio" split_words:"true"`
 
 	NetworkServices       []url.URL               `default:"" desc:"A list of Network Service Requests" split_words:"true"`
+	OneShot               bool                    `default:"false" desc:"Exit once all Network Services are connected, without closing the connections" split_words:"true"`
+	RequestConcurrency    int                     `default:"0" desc:"Maximum number of Network Services requested concurrently, 0 means no limit" split_words:"true"`
 	AwarenessGroups       awarenessgroups.Decoder `defailt:"" desc:"Awareness groups for mutually aware NSEs" split_words:"true"`
 	LogLevel              string                  `default:"INFO" desc:"Log level" split_words:"true"`
+	LogFormat             string                  `default:"nested" desc:"Log format: json, text or nested" split_words:"true"`
 	OpenTelemetryEndpoint string                  `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true"`
 	MetricsExportInterval time.Duration           `default:"10s" desc:"interval between mertics exports" split_words:"true"`
 
 	LocalDNSServerEnabled bool   `default:"true" desc:"Local DNS Server enabled/disabled" split_words:"true"`
 	LocalDNSServerAddress string `default:"127.0.0.1:53" desc:"Default address for local DNS server" split_words:"true"`
+	ResolvConfManaged     bool   `default:"false" desc:"Point resolv.conf at the local DNS server and restore it on shutdown" split_words:"true"`
+	ResolvConfPath        string `default:"/etc/resolv.conf" desc:"Path to resolv.conf managed for the local DNS server" split_words:"true"`
+
+	LocalDNSCacheEnabled    bool          `default:"true" desc:"Local DNS server cache enabled/disabled" split_words:"true"`
+	LocalDNSCacheSize       int           `default:"1024" desc:"Maximum number of responses cached by the local DNS server" split_words:"true"`
+	LocalDNSCacheMaxTTL     time.Duration `default:"5m" desc:"Maximum time a response is cached by the local DNS server" split_words:"true"`
+	LocalDNSUpstreamMode    string        `default:"fanout" desc:"Local DNS server upstream mode: fanout or sequential" split_words:"true"`
+	LocalDNSUpstreamTimeout time.Duration `default:"5s" desc:"Timeout of a single upstream DNS query" split_words:"true"`
+	LocalDNSBufferSize      uint16        `default:"1232" desc:"EDNS0 UDP buffer size advertised to the upstream DNS servers" split_words:"true"`
+	LocalDNSHostsFile       string        `default:"" desc:"Path to hosts file with static records served by the local DNS server" split_words:"true"`
+	LocalDNSRewrites        []string      `default:"" desc:"A list of domain=ip[:port] rules sending the queries of the domain to the DNS server" split_words:"true"`
+	LocalDNSBlockedSuffixes []string      `default:"" desc:"A list of domain suffixes answered NXDOMAIN by the local DNS server" split_words:"true"`
+
+	LocalDNSQueryLogEnabled    bool    `default:"false" desc:"Log the local DNS server queries with the upstream server and the Network Service answered them" split_words:"true"`
+	LocalDNSQueryLogSampleRate float64 `default:"1" desc:"Part of the local DNS server queries logged in [0, 1]" split_words:"true"`
+	LocalDNSQueryLogRateLimit  int     `default:"100" desc:"Maximum number of the local DNS server queries logged per second, 0 means no limit" split_words:"true"`
 
 	LivenessCheckEnabled  bool          `default:"true" desc:"Dataplane liveness check enabled/disabled" split_words:"true"`
 	LivenessCheckInterval time.Duration `default:"200ms" desc:"Dataplane liveness check interval" split_words:"true"`
 	LivenessCheckTimeout  time.Duration `default:"1s" desc:"Dataplane liveness check timeout" split_words:"true"`
 
+	ControlSocket string `default:"" desc:"Path to unix socket of the local control API, empty disables it" split_words:"true"`
+
+	Hooks              []string      `default:"" desc:"A list of executables run on the connection lifecycle events" split_words:"true"`
+	HooksDir           string        `default:"" desc:"Directory of executables run on the connection lifecycle events in lexical order" split_words:"true"`
+	HooksTimeout       time.Duration `default:"10s" desc:"Timeout of a single hook run" split_words:"true"`
+	HooksFailurePolicy string        `default:"ignore" desc:"Hook failure policy: ignore or exit" split_words:"true"`
+
+	Webhooks           []string      `default:"" desc:"A list of http(s) URLs receiving the connection lifecycle events" split_words:"true"`
+	WebhookSecret      string        `default:"" desc:"Key of HMAC-SHA256 signature of the webhook requests, empty disables signing" split_words:"true"`
+	WebhookTimeout     time.Duration `default:"5s" desc:"Timeout of a single webhook request" split_words:"true"`
+	WebhookMaxAttempts int           `default:"5" desc:"Maximum number of delivery attempts of an event to a webhook" split_words:"true"`
+	WebhookQueueSize   int           `default:"100" desc:"Maximum number of queued webhook events, the new events are dropped if the queue is full" split_words:"true"`
+
+	StatusFile string `default:"" desc:"Path to JSON or YAML (.yaml, .yml) connection status file, empty disables it" split_words:"true"`
+	StateFile  string `default:"" desc:"Path to file persisting the established connections across the restarts, empty disables it" split_words:"true"`
+
+	HealthEnabled            bool     `default:"false" desc:"Health probes /healthz, /readyz and /livez enabled/disabled" split_words:"true"`
+	HealthListenOn           string   `default:":8080" desc:"Health probes address to ListenAndServe" split_words:"true"`
+	ReadinessNetworkServices []string `default:"" desc:"Names of Network Services required to be connected for readiness, empty means all of them" split_words:"true"`
+
+	PrometheusEnabled  bool   `default:"false" desc:"Prometheus /metrics endpoint enabled/disabled" split_words:"true"`
+	PrometheusListenOn string `default:":9090" desc:"Prometheus /metrics address to ListenAndServe" split_words:"true"`
+
 	PprofEnabled  bool   `default:"false" desc:"is pprof enabled" split_words:"true"`
 	PprofListenOn string `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true"`
+
+	DebugEnabled  bool   `default:"false" desc:"Debug /debug/dns endpoint dumping the DNS configs per connection enabled/disabled" split_words:"true"`
+	DebugListenOn string `default:"localhost:6061" desc:"Debug endpoint address to ListenAndServe" split_words:"true"`
 }
 
-// IsValid - check if configuration is valid
+// Load - loads the configuration from the environment variables and the configuration file, and validates it
+func Load() (*Config, error) {
+	c := &Config{}
+	if err := envconfig.Process(strings.TrimSuffix(envPrefix, "_"), c); err != nil {
+		return nil, errors.Wrap(err, "error processing config from env")
+	}
+	if c.ConfigFile != "" {
+		if err := c.LoadFile(c.ConfigFile); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.ApplyDefaultMechanism(); err != nil {
+		return nil, err
+	}
+	if err := c.IsValid(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// IsValid - check if configuration is valid, all found problems are reported at once
 func (c *Config) IsValid() error {
+	var problems []string
 	if len(c.NetworkServices) == 0 {
-		return errors.New("no network services are specified")
+		problems = append(problems, "no network services are specified")
 	}
 	if c.Name == "" {
-		return errors.New("no client name specified")
+		problems = append(problems, "no client name specified")
+	}
+	if len(c.ConnectTo) == 0 {
+		problems = append(problems, "no NSMGr ConnectTO URL are specified")
+	}
+	problems = append(problems, c.validateConnectTo()...)
+	if _, err := peerauth.NewAuthorizer(c.AllowedServers); err != nil {
+		problems = append(problems, err.Error())
+	}
+	problems = append(problems, c.validateLogFormat()...)
+	problems = append(problems, c.validateSVIDFiles()...)
+	problems = append(problems, c.validateResolvConf()...)
+	problems = append(problems, c.validateLocalDNS()...)
+	problems = append(problems, c.validateHooks()...)
+	problems = append(problems, c.validateWebhooks()...)
+	if _, err := c.LabelsMap(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	problems = append(problems, c.validateDurations()...)
+	problems = append(problems, c.validateRequestRetries()...)
+	problems = append(problems, c.validateNetworkServices()...)
+
+	if len(problems) != 0 {
+		return errors.Errorf("invalid configuration:\n\t%s", strings.Join(problems, "\n\t"))
 	}
-	if c.ConnectTo.String() == "" {
-		return errors.New("no NSMGr ConnectTO URL are specified")
+	return nil
+}
+
+// ConnectToString - returns ConnectTo URLs separated by commas
+func (c *Config) ConnectToString() string {
+	urls := make([]string, 0, len(c.ConnectTo))
+	for i := range c.ConnectTo {
+		urls = append(urls, c.ConnectTo[i].String())
+	}
+	return strings.Join(urls, ",")
+}
+
+// ApplyDefaultMechanism - applies the default Mechanism to the Network Service URLs without a scheme
+func (c *Config) ApplyDefaultMechanism() error {
+	for i := range c.NetworkServices {
+		u := &c.NetworkServices[i]
+		if u.Scheme != "" || c.Mechanism == "" {
+			continue
+		}
+		withScheme, err := url.Parse(strings.ToLower(c.Mechanism) + "://" + u.String())
+		if err != nil {
+			return errors.Wrapf(err, "failed to apply default mechanism to %s", u.String())
+		}
+		*u = *withScheme
 	}
 	return nil
 }
+
+// LabelsMap - returns client labels as a map
+func (c *Config) LabelsMap() (map[string]string, error) {
+	labels := make(map[string]string, len(c.Labels))
+	for _, label := range c.Labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, errors.Errorf("invalid label %q, expected format: key=value", label)
+		}
+		labels[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return labels, nil
+}
@@ -0,0 +1,439 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const envPrefix = "NSM_"
+
+// duration - time.Duration unmarshalled from strings like "15s"
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrapf(err, "invalid duration %s", string(data))
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.Wrapf(err, "invalid duration %s", s)
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+// stringList - list unmarshalled from a single string or a list of strings
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*l = stringList{s}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// fileNetworkService - Network Service in the configuration file, either a URL string or a structured section
+type fileNetworkService struct {
+	URL            string            `json:"url,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Mechanism      string            `json:"mechanism,omitempty"`
+	Interface      string            `json:"interface,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	RequestTimeout *duration         `json:"requestTimeout,omitempty"`
+
+	LivenessCheckEnabled  *bool     `json:"livenessCheckEnabled,omitempty"`
+	LivenessCheckInterval *duration `json:"livenessCheckInterval,omitempty"`
+	LivenessCheckTimeout  *duration `json:"livenessCheckTimeout,omitempty"`
+	LocalDNSServerEnabled *bool     `json:"localDnsServerEnabled,omitempty"`
+}
+
+func (s *fileNetworkService) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.URL); err == nil {
+		return nil
+	}
+	type plain fileNetworkService
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+func (s *fileNetworkService) toURL() (*url.URL, error) {
+	if s.URL != "" && s.Name != "" {
+		return nil, errors.New("only one of url and name can be specified")
+	}
+
+	var u *url.URL
+	var err error
+	switch {
+	case s.URL != "":
+		u, err = url.Parse(s.URL)
+	case s.Name != "":
+		rawURL := s.Name
+		if s.Interface != "" {
+			rawURL += "/" + s.Interface
+		}
+		if s.Mechanism != "" {
+			rawURL = strings.ToLower(s.Mechanism) + "://" + rawURL
+		}
+		u, err = url.Parse(rawURL)
+	default:
+		return nil, errors.New("url or name should be specified")
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	query := u.Query()
+	for k, v := range s.Labels {
+		query.Set(k, v)
+	}
+	if s.RequestTimeout != nil {
+		query.Set(RequestTimeoutParam, time.Duration(*s.RequestTimeout).String())
+	}
+	if s.LivenessCheckEnabled != nil {
+		query.Set(LivenessCheckEnabledParam, strconv.FormatBool(*s.LivenessCheckEnabled))
+	}
+	if s.LivenessCheckInterval != nil {
+		query.Set(LivenessCheckIntervalParam, time.Duration(*s.LivenessCheckInterval).String())
+	}
+	if s.LivenessCheckTimeout != nil {
+		query.Set(LivenessCheckTimeoutParam, time.Duration(*s.LivenessCheckTimeout).String())
+	}
+	if s.LocalDNSServerEnabled != nil {
+		query.Set(LocalDNSServerEnabledParam, strconv.FormatBool(*s.LocalDNSServerEnabled))
+	}
+	if len(query) != 0 {
+		u.RawQuery = query.Encode()
+	}
+	return u, nil
+}
+
+// fileConfig - configuration file, its fields are named after the Config fields
+type fileConfig struct {
+	Name             *string    `json:"name,omitempty"`
+	ConnectTo        stringList `json:"connectTo,omitempty"`
+	DialTimeout      *duration  `json:"dialTimeout,omitempty"`
+	RequestTimeout   *duration  `json:"requestTimeout,omitempty"`
+	MaxTokenLifetime *duration  `json:"maxTokenLifetime,omitempty"`
+	AllowedServers   []string   `json:"allowedServers,omitempty"`
+
+	ConfigFileWatchInterval *duration `json:"configFileWatchInterval,omitempty"`
+
+	SVIDCertFile          *string   `json:"svidCertFile,omitempty"`
+	SVIDKeyFile           *string   `json:"svidKeyFile,omitempty"`
+	SVIDBundleFile        *string   `json:"svidBundleFile,omitempty"`
+	SVIDFileWatchInterval *duration `json:"svidFileWatchInterval,omitempty"`
+
+	RequestBackoffInitialDelay *duration `json:"requestBackoffInitialDelay,omitempty"`
+	RequestBackoffMaxDelay     *duration `json:"requestBackoffMaxDelay,omitempty"`
+	RequestBackoffMultiplier   *float64  `json:"requestBackoffMultiplier,omitempty"`
+	RequestBackoffJitter       *float64  `json:"requestBackoffJitter,omitempty"`
+	RequestMaxAttempts         *int      `json:"requestMaxAttempts,omitempty"`
+	RequestMaxTotalAttempts    *int      `json:"requestMaxTotalAttempts,omitempty"`
+
+	Labels    map[string]string `json:"labels,omitempty"`
+	Mechanism *string           `json:"mechanism,omitempty"`
+
+	NetworkServices       []fileNetworkService `json:"networkServices,omitempty"`
+	OneShot               *bool                `json:"oneShot,omitempty"`
+	RequestConcurrency    *int                 `json:"requestConcurrency,omitempty"`
+	AwarenessGroups       *string              `json:"awarenessGroups,omitempty"`
+	LogLevel              *string              `json:"logLevel,omitempty"`
+	LogFormat             *string              `json:"logFormat,omitempty"`
+	OpenTelemetryEndpoint *string              `json:"openTelemetryEndpoint,omitempty"`
+	MetricsExportInterval *duration            `json:"metricsExportInterval,omitempty"`
+
+	LocalDNSServerEnabled *bool   `json:"localDnsServerEnabled,omitempty"`
+	LocalDNSServerAddress *string `json:"localDnsServerAddress,omitempty"`
+	ResolvConfManaged     *bool   `json:"resolvConfManaged,omitempty"`
+	ResolvConfPath        *string `json:"resolvConfPath,omitempty"`
+
+	LocalDNSCacheEnabled    *bool     `json:"localDnsCacheEnabled,omitempty"`
+	LocalDNSCacheSize       *int      `json:"localDnsCacheSize,omitempty"`
+	LocalDNSCacheMaxTTL     *duration `json:"localDnsCacheMaxTtl,omitempty"`
+	LocalDNSUpstreamMode    *string   `json:"localDnsUpstreamMode,omitempty"`
+	LocalDNSUpstreamTimeout *duration `json:"localDnsUpstreamTimeout,omitempty"`
+	LocalDNSBufferSize      *uint16   `json:"localDnsBufferSize,omitempty"`
+	LocalDNSHostsFile       *string   `json:"localDnsHostsFile,omitempty"`
+	LocalDNSRewrites        []string  `json:"localDnsRewrites,omitempty"`
+	LocalDNSBlockedSuffixes []string  `json:"localDnsBlockedSuffixes,omitempty"`
+
+	LocalDNSQueryLogEnabled    *bool    `json:"localDnsQueryLogEnabled,omitempty"`
+	LocalDNSQueryLogSampleRate *float64 `json:"localDnsQueryLogSampleRate,omitempty"`
+	LocalDNSQueryLogRateLimit  *int     `json:"localDnsQueryLogRateLimit,omitempty"`
+
+	LivenessCheckEnabled  *bool     `json:"livenessCheckEnabled,omitempty"`
+	LivenessCheckInterval *duration `json:"livenessCheckInterval,omitempty"`
+	LivenessCheckTimeout  *duration `json:"livenessCheckTimeout,omitempty"`
+
+	StatusFile *string `json:"statusFile,omitempty"`
+	StateFile  *string `json:"stateFile,omitempty"`
+
+	Hooks              []string  `json:"hooks,omitempty"`
+	HooksDir           *string   `json:"hooksDir,omitempty"`
+	HooksTimeout       *duration `json:"hooksTimeout,omitempty"`
+	HooksFailurePolicy *string   `json:"hooksFailurePolicy,omitempty"`
+
+	Webhooks           []string  `json:"webhooks,omitempty"`
+	WebhookSecret      *string   `json:"webhookSecret,omitempty"`
+	WebhookTimeout     *duration `json:"webhookTimeout,omitempty"`
+	WebhookMaxAttempts *int      `json:"webhookMaxAttempts,omitempty"`
+	WebhookQueueSize   *int      `json:"webhookQueueSize,omitempty"`
+
+	HealthEnabled            *bool    `json:"healthEnabled,omitempty"`
+	HealthListenOn           *string  `json:"healthListenOn,omitempty"`
+	ReadinessNetworkServices []string `json:"readinessNetworkServices,omitempty"`
+
+	PrometheusEnabled  *bool   `json:"prometheusEnabled,omitempty"`
+	PrometheusListenOn *string `json:"prometheusListenOn,omitempty"`
+
+	PprofEnabled  *bool   `json:"pprofEnabled,omitempty"`
+	PprofListenOn *string `json:"pprofListenOn,omitempty"`
+
+	DebugEnabled  *bool   `json:"debugEnabled,omitempty"`
+	DebugListenOn *string `json:"debugListenOn,omitempty"`
+
+	ControlSocket *string `json:"controlSocket,omitempty"`
+}
+
+// LoadFile - loads YAML or JSON configuration file, values set by the environment variables are not overridden
+func (c *Config) LoadFile(path string) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read config file %s", path)
+	}
+
+	fc := new(fileConfig)
+	if err := yaml.UnmarshalStrict(data, fc); err != nil {
+		return errors.Wrapf(err, "failed to parse config file %s", path)
+	}
+
+	return errors.Wrapf(fc.applyTo(c), "invalid config file %s", path)
+}
+
+func (fc *fileConfig) applyTo(c *Config) error {
+	setValue("NAME", fc.Name, &c.Name)
+	setValue("DIAL_TIMEOUT", (*time.Duration)(fc.DialTimeout), &c.DialTimeout)
+	setValue("REQUEST_TIMEOUT", (*time.Duration)(fc.RequestTimeout), &c.RequestTimeout)
+	setValue("MAX_TOKEN_LIFETIME", (*time.Duration)(fc.MaxTokenLifetime), &c.MaxTokenLifetime)
+	setList("ALLOWED_SERVERS", fc.AllowedServers, &c.AllowedServers)
+	setValue("CONFIG_FILE_WATCH_INTERVAL", (*time.Duration)(fc.ConfigFileWatchInterval), &c.ConfigFileWatchInterval)
+
+	setValue("SVID_CERT_FILE", fc.SVIDCertFile, &c.SVIDCertFile)
+	setValue("SVID_KEY_FILE", fc.SVIDKeyFile, &c.SVIDKeyFile)
+	setValue("SVID_BUNDLE_FILE", fc.SVIDBundleFile, &c.SVIDBundleFile)
+	setValue("SVID_FILE_WATCH_INTERVAL", (*time.Duration)(fc.SVIDFileWatchInterval), &c.SVIDFileWatchInterval)
+
+	setValue("MECHANISM", fc.Mechanism, &c.Mechanism)
+	setValue("ONE_SHOT", fc.OneShot, &c.OneShot)
+	setValue("LOG_LEVEL", fc.LogLevel, &c.LogLevel)
+	setValue("LOG_FORMAT", fc.LogFormat, &c.LogFormat)
+	setValue("OPEN_TELEMETRY_ENDPOINT", fc.OpenTelemetryEndpoint, &c.OpenTelemetryEndpoint)
+	setValue("METRICS_EXPORT_INTERVAL", (*time.Duration)(fc.MetricsExportInterval), &c.MetricsExportInterval)
+
+	setValue("LIVENESS_CHECK_ENABLED", fc.LivenessCheckEnabled, &c.LivenessCheckEnabled)
+	setValue("LIVENESS_CHECK_INTERVAL", (*time.Duration)(fc.LivenessCheckInterval), &c.LivenessCheckInterval)
+	setValue("LIVENESS_CHECK_TIMEOUT", (*time.Duration)(fc.LivenessCheckTimeout), &c.LivenessCheckTimeout)
+
+	setValue("STATUS_FILE", fc.StatusFile, &c.StatusFile)
+	setValue("STATE_FILE", fc.StateFile, &c.StateFile)
+
+	fc.applyRequestsTo(c)
+	fc.applyDNSTo(c)
+	fc.applyHooksTo(c)
+	fc.applyWebhooksTo(c)
+	fc.applyEndpointsTo(c)
+	return fc.applyNetworkServicesTo(c)
+}
+
+// applyRequestsTo - applies the request retry and concurrency settings
+func (fc *fileConfig) applyRequestsTo(c *Config) {
+	setValue("REQUEST_BACKOFF_INITIAL_DELAY", (*time.Duration)(fc.RequestBackoffInitialDelay), &c.RequestBackoffInitialDelay)
+	setValue("REQUEST_BACKOFF_MAX_DELAY", (*time.Duration)(fc.RequestBackoffMaxDelay), &c.RequestBackoffMaxDelay)
+	setValue("REQUEST_BACKOFF_MULTIPLIER", fc.RequestBackoffMultiplier, &c.RequestBackoffMultiplier)
+	setValue("REQUEST_BACKOFF_JITTER", fc.RequestBackoffJitter, &c.RequestBackoffJitter)
+	setValue("REQUEST_MAX_ATTEMPTS", fc.RequestMaxAttempts, &c.RequestMaxAttempts)
+	setValue("REQUEST_MAX_TOTAL_ATTEMPTS", fc.RequestMaxTotalAttempts, &c.RequestMaxTotalAttempts)
+	setValue("REQUEST_CONCURRENCY", fc.RequestConcurrency, &c.RequestConcurrency)
+}
+
+// applyDNSTo - applies the local DNS server and resolv.conf settings
+func (fc *fileConfig) applyDNSTo(c *Config) {
+	setValue("LOCAL_DNS_SERVER_ENABLED", fc.LocalDNSServerEnabled, &c.LocalDNSServerEnabled)
+	setValue("LOCAL_DNS_SERVER_ADDRESS", fc.LocalDNSServerAddress, &c.LocalDNSServerAddress)
+	setValue("RESOLV_CONF_MANAGED", fc.ResolvConfManaged, &c.ResolvConfManaged)
+	setValue("RESOLV_CONF_PATH", fc.ResolvConfPath, &c.ResolvConfPath)
+
+	setValue("LOCAL_DNS_CACHE_ENABLED", fc.LocalDNSCacheEnabled, &c.LocalDNSCacheEnabled)
+	setValue("LOCAL_DNS_CACHE_SIZE", fc.LocalDNSCacheSize, &c.LocalDNSCacheSize)
+	setValue("LOCAL_DNS_CACHE_MAX_TTL", (*time.Duration)(fc.LocalDNSCacheMaxTTL), &c.LocalDNSCacheMaxTTL)
+	setValue("LOCAL_DNS_UPSTREAM_MODE", fc.LocalDNSUpstreamMode, &c.LocalDNSUpstreamMode)
+	setValue("LOCAL_DNS_UPSTREAM_TIMEOUT", (*time.Duration)(fc.LocalDNSUpstreamTimeout), &c.LocalDNSUpstreamTimeout)
+	setValue("LOCAL_DNS_BUFFER_SIZE", fc.LocalDNSBufferSize, &c.LocalDNSBufferSize)
+	setValue("LOCAL_DNS_HOSTS_FILE", fc.LocalDNSHostsFile, &c.LocalDNSHostsFile)
+	setList("LOCAL_DNS_REWRITES", fc.LocalDNSRewrites, &c.LocalDNSRewrites)
+	setList("LOCAL_DNS_BLOCKED_SUFFIXES", fc.LocalDNSBlockedSuffixes, &c.LocalDNSBlockedSuffixes)
+
+	setValue("LOCAL_DNS_QUERY_LOG_ENABLED", fc.LocalDNSQueryLogEnabled, &c.LocalDNSQueryLogEnabled)
+	setValue("LOCAL_DNS_QUERY_LOG_SAMPLE_RATE", fc.LocalDNSQueryLogSampleRate, &c.LocalDNSQueryLogSampleRate)
+	setValue("LOCAL_DNS_QUERY_LOG_RATE_LIMIT", fc.LocalDNSQueryLogRateLimit, &c.LocalDNSQueryLogRateLimit)
+}
+
+// applyHooksTo - applies the lifecycle hooks settings
+func (fc *fileConfig) applyHooksTo(c *Config) {
+	setList("HOOKS", fc.Hooks, &c.Hooks)
+	setValue("HOOKS_DIR", fc.HooksDir, &c.HooksDir)
+	setValue("HOOKS_TIMEOUT", (*time.Duration)(fc.HooksTimeout), &c.HooksTimeout)
+	setValue("HOOKS_FAILURE_POLICY", fc.HooksFailurePolicy, &c.HooksFailurePolicy)
+}
+
+// applyWebhooksTo - applies the webhooks settings
+func (fc *fileConfig) applyWebhooksTo(c *Config) {
+	setList("WEBHOOKS", fc.Webhooks, &c.Webhooks)
+	setValue("WEBHOOK_SECRET", fc.WebhookSecret, &c.WebhookSecret)
+	setValue("WEBHOOK_TIMEOUT", (*time.Duration)(fc.WebhookTimeout), &c.WebhookTimeout)
+	setValue("WEBHOOK_MAX_ATTEMPTS", fc.WebhookMaxAttempts, &c.WebhookMaxAttempts)
+	setValue("WEBHOOK_QUEUE_SIZE", fc.WebhookQueueSize, &c.WebhookQueueSize)
+}
+
+// applyEndpointsTo - applies the health, metrics, pprof, debug and control API endpoints settings
+func (fc *fileConfig) applyEndpointsTo(c *Config) {
+	setValue("HEALTH_ENABLED", fc.HealthEnabled, &c.HealthEnabled)
+	setValue("HEALTH_LISTEN_ON", fc.HealthListenOn, &c.HealthListenOn)
+	setList("READINESS_NETWORK_SERVICES", fc.ReadinessNetworkServices, &c.ReadinessNetworkServices)
+
+	setValue("PROMETHEUS_ENABLED", fc.PrometheusEnabled, &c.PrometheusEnabled)
+	setValue("PROMETHEUS_LISTEN_ON", fc.PrometheusListenOn, &c.PrometheusListenOn)
+
+	setValue("PPROF_ENABLED", fc.PprofEnabled, &c.PprofEnabled)
+	setValue("PPROF_LISTEN_ON", fc.PprofListenOn, &c.PprofListenOn)
+
+	setValue("DEBUG_ENABLED", fc.DebugEnabled, &c.DebugEnabled)
+	setValue("DEBUG_LISTEN_ON", fc.DebugListenOn, &c.DebugListenOn)
+
+	setValue("CONTROL_SOCKET", fc.ControlSocket, &c.ControlSocket)
+}
+
+// applyNetworkServicesTo - applies the NSMgr URLs, the awareness groups, the labels and the Network Services, which
+// need parsing
+func (fc *fileConfig) applyNetworkServicesTo(c *Config) error {
+	if fc.ConnectTo != nil && !isEnvSet("CONNECT_TO") {
+		c.ConnectTo = make([]url.URL, 0, len(fc.ConnectTo))
+		for _, rawURL := range fc.ConnectTo {
+			u, err := url.Parse(rawURL)
+			if err != nil {
+				return errors.Wrap(err, "invalid connectTo")
+			}
+			c.ConnectTo = append(c.ConnectTo, *u)
+		}
+	}
+
+	if fc.AwarenessGroups != nil && !isEnvSet("AWARENESS_GROUPS") {
+		if err := c.AwarenessGroups.Decode(*fc.AwarenessGroups); err != nil {
+			return errors.Wrap(err, "invalid awarenessGroups")
+		}
+	}
+
+	if fc.Labels != nil && !isEnvSet("LABELS") {
+		c.Labels = make([]string, 0, len(fc.Labels))
+		for k, v := range fc.Labels {
+			c.Labels = append(c.Labels, k+"="+v)
+		}
+		sort.Strings(c.Labels)
+	}
+
+	if fc.NetworkServices != nil && !isEnvSet("NETWORK_SERVICES") {
+		c.NetworkServices = make([]url.URL, 0, len(fc.NetworkServices))
+		for i := range fc.NetworkServices {
+			u, err := fc.NetworkServices[i].toURL()
+			if err != nil {
+				return errors.Wrapf(err, "invalid networkServices[%d]", i)
+			}
+			c.NetworkServices = append(c.NetworkServices, *u)
+		}
+	}
+	return nil
+}
+
+func setValue[T any](envName string, value, target *T) {
+	if value == nil || isEnvSet(envName) {
+		return
+	}
+	*target = *value
+}
+
+func setList(envName string, value []string, target *[]string) {
+	if value == nil || isEnvSet(envName) {
+		return
+	}
+	*target = value
+}
+
+func isEnvSet(envName string) bool {
+	_, ok := os.LookupEnv(envPrefix + envName)
+	return ok
+}
+
+// WatchFile - checks the file every interval and notifies when its content changes
+func WatchFile(ctx context.Context, path string, interval time.Duration) <-chan struct{} {
+	changes := make(chan struct{}, 1)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		hash := fileHash(path)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if newHash := fileHash(path); newHash != hash {
+				hash = newHash
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return changes
+}
+
+func fileHash(path string) [sha256.Size]byte {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(data)
+}
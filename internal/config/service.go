@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ReservedParamPrefix - prefix of the Network Service URL query parameters reserved for nsc, such parameters
+	// are not sent as labels
+	ReservedParamPrefix = "nsc-"
+	// RequestTimeoutParam - overrides RequestTimeout for the Network Service
+	RequestTimeoutParam = ReservedParamPrefix + "request-timeout"
+	// LivenessCheckEnabledParam - overrides LivenessCheckEnabled for the Network Service
+	LivenessCheckEnabledParam = ReservedParamPrefix + "liveness-check-enabled"
+	// LivenessCheckIntervalParam - overrides LivenessCheckInterval for the Network Service
+	LivenessCheckIntervalParam = ReservedParamPrefix + "liveness-check-interval"
+	// LivenessCheckTimeoutParam - overrides LivenessCheckTimeout for the Network Service
+	LivenessCheckTimeoutParam = ReservedParamPrefix + "liveness-check-timeout"
+	// LocalDNSServerEnabledParam - overrides LocalDNSServerEnabled for the Network Service
+	LocalDNSServerEnabledParam = ReservedParamPrefix + "local-dns-server-enabled"
+)
+
+// NetworkService - configuration of a single Network Service
+type NetworkService struct {
+	// URL - Network Service URL without the reserved parameters
+	URL *url.URL
+	// Labels - client labels, the URL labels take precedence
+	Labels map[string]string
+	// RequestTimeout - timeout to request the Network Service
+	RequestTimeout time.Duration
+	// LivenessCheckEnabled - dataplane liveness check enabled/disabled
+	LivenessCheckEnabled bool
+	// LivenessCheckInterval - dataplane liveness check interval
+	LivenessCheckInterval time.Duration
+	// LivenessCheckTimeout - dataplane liveness check timeout
+	LivenessCheckTimeout time.Duration
+	// LocalDNSServerEnabled - DNS configs of the connection are used by the local DNS server
+	LocalDNSServerEnabled bool
+}
+
+// Services - returns configuration of each Network Service, the global values are overridden by the URL query
+// parameters reserved for nsc
+func (c *Config) Services() ([]*NetworkService, error) {
+	services := make([]*NetworkService, 0, len(c.NetworkServices))
+	for i := range c.NetworkServices {
+		service, err := c.service(&c.NetworkServices[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", c.NetworkServices[i].String())
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// ParseNetworkService - parses the Network Service URL, applies the default Mechanism and the global values not
+// overridden by the reserved parameters, and validates the result
+func (c *Config) ParseNetworkService(rawURL string) (*NetworkService, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid network service URL %s", rawURL)
+	}
+	if u.Scheme == "" && c.Mechanism != "" {
+		if u, err = url.Parse(strings.ToLower(c.Mechanism) + "://" + rawURL); err != nil {
+			return nil, errors.Wrapf(err, "failed to apply default mechanism to %s", rawURL)
+		}
+	}
+
+	service, err := c.service(u)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", rawURL)
+	}
+	if problems := append(service.validate(), c.validateOverrides(service)...); len(problems) != 0 {
+		return nil, errors.Errorf("%s: %s", rawURL, strings.Join(problems, "; "))
+	}
+	return service, nil
+}
+
+func (c *Config) service(u *url.URL) (*NetworkService, error) {
+	labels, err := c.LabelsMap()
+	if err != nil {
+		return nil, err
+	}
+	service := &NetworkService{
+		URL:                   new(url.URL),
+		Labels:                labels,
+		RequestTimeout:        c.RequestTimeout,
+		LivenessCheckEnabled:  c.LivenessCheckEnabled,
+		LivenessCheckInterval: c.LivenessCheckInterval,
+		LivenessCheckTimeout:  c.LivenessCheckTimeout,
+		LocalDNSServerEnabled: c.LocalDNSServerEnabled,
+	}
+	*service.URL = *u
+
+	query := u.Query()
+	reserved := false
+	for key := range query {
+		if !strings.HasPrefix(key, ReservedParamPrefix) {
+			continue
+		}
+		reserved = true
+		value := query.Get(key)
+		query.Del(key)
+
+		var err error
+		switch key {
+		case RequestTimeoutParam:
+			service.RequestTimeout, err = time.ParseDuration(value)
+		case LivenessCheckEnabledParam:
+			service.LivenessCheckEnabled, err = strconv.ParseBool(value)
+		case LivenessCheckIntervalParam:
+			service.LivenessCheckInterval, err = time.ParseDuration(value)
+		case LivenessCheckTimeoutParam:
+			service.LivenessCheckTimeout, err = time.ParseDuration(value)
+		case LocalDNSServerEnabledParam:
+			service.LocalDNSServerEnabled, err = strconv.ParseBool(value)
+		default:
+			err = errors.New("unknown parameter")
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s=%s", key, value)
+		}
+	}
+	if reserved {
+		service.URL.RawQuery = query.Encode()
+	}
+
+	return service, nil
+}
+
+// LocalDNSServerRequired - returns true if the local DNS server is enabled globally or for any Network Service
+func (c *Config) LocalDNSServerRequired() bool {
+	if c.LocalDNSServerEnabled {
+		return true
+	}
+	for i := range c.NetworkServices {
+		if service, err := c.service(&c.NetworkServices[i]); err == nil && service.LocalDNSServerEnabled {
+			return true
+		}
+	}
+	return false
+}
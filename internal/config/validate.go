@@ -0,0 +1,306 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	vfiomech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vfio"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsurl"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/localdns"
+)
+
+const (
+	// ifNameSize - IFNAMSIZ, maximum length of the kernel interface name including the terminating NUL
+	ifNameSize = 16
+	// sriovTokenLabel - label required by the vfio mechanism
+	sriovTokenLabel = "sriovToken"
+)
+
+var logFormats = map[string]bool{
+	LogFormatJSON:   true,
+	LogFormatText:   true,
+	LogFormatNested: true,
+}
+
+var supportedMechanisms = map[string]bool{
+	kernelmech.MECHANISM: true,
+	vfiomech.MECHANISM:   true,
+}
+
+func (c *Config) validateDurations() (problems []string) {
+	positive := func(name string, d time.Duration) {
+		if d <= 0 {
+			problems = append(problems, fmt.Sprintf("%s should be positive, got %v", name, d))
+		}
+	}
+	positive("DialTimeout", c.DialTimeout)
+	positive("RequestTimeout", c.RequestTimeout)
+	positive("MaxTokenLifetime", c.MaxTokenLifetime)
+	positive("RequestBackoffInitialDelay", c.RequestBackoffInitialDelay)
+	positive("RequestBackoffMaxDelay", c.RequestBackoffMaxDelay)
+	if len(c.Hooks) != 0 || c.HooksDir != "" {
+		positive("HooksTimeout", c.HooksTimeout)
+	}
+	if len(c.Webhooks) != 0 {
+		positive("WebhookTimeout", c.WebhookTimeout)
+	}
+	if c.LivenessCheckEnabled {
+		positive("LivenessCheckInterval", c.LivenessCheckInterval)
+		positive("LivenessCheckTimeout", c.LivenessCheckTimeout)
+	}
+	return problems
+}
+
+func (c *Config) validateRequestRetries() (problems []string) {
+	if c.RequestBackoffMultiplier < 1 {
+		problems = append(problems, fmt.Sprintf("RequestBackoffMultiplier should be at least 1, got %v", c.RequestBackoffMultiplier))
+	}
+	if c.RequestBackoffJitter < 0 || c.RequestBackoffJitter > 1 {
+		problems = append(problems, fmt.Sprintf("RequestBackoffJitter should be in [0, 1], got %v", c.RequestBackoffJitter))
+	}
+	if c.RequestMaxAttempts < 0 {
+		problems = append(problems, fmt.Sprintf("RequestMaxAttempts should not be negative, got %v", c.RequestMaxAttempts))
+	}
+	if c.RequestMaxTotalAttempts < 0 {
+		problems = append(problems, fmt.Sprintf("RequestMaxTotalAttempts should not be negative, got %v", c.RequestMaxTotalAttempts))
+	}
+	if c.RequestConcurrency < 0 {
+		problems = append(problems, fmt.Sprintf("RequestConcurrency should not be negative, got %v", c.RequestConcurrency))
+	}
+	// One-shot mode exits only once every request succeeds or the retries are exhausted
+	if c.OneShot && c.RequestMaxAttempts == 0 && c.RequestMaxTotalAttempts == 0 {
+		problems = append(problems, "RequestMaxAttempts or RequestMaxTotalAttempts should be set in one-shot mode")
+	}
+	return problems
+}
+
+func (c *Config) validateLogFormat() (problems []string) {
+	if !logFormats[c.LogFormat] {
+		problems = append(problems, fmt.Sprintf("LogFormat should be one of json, text and nested, got %q", c.LogFormat))
+	}
+	return problems
+}
+
+func (c *Config) validateSVIDFiles() (problems []string) {
+	if c.SVIDCertFile == "" && c.SVIDKeyFile == "" && c.SVIDBundleFile == "" {
+		return nil
+	}
+	if c.SVIDCertFile == "" || c.SVIDKeyFile == "" || c.SVIDBundleFile == "" {
+		problems = append(problems, "SVIDCertFile, SVIDKeyFile and SVIDBundleFile should be set together")
+	}
+	if c.SVIDFileWatchInterval <= 0 {
+		problems = append(problems, fmt.Sprintf("SVIDFileWatchInterval should be positive, got %v", c.SVIDFileWatchInterval))
+	}
+	return problems
+}
+
+func (c *Config) validateResolvConf() (problems []string) {
+	if !c.ResolvConfManaged || !c.LocalDNSServerRequired() {
+		return nil
+	}
+	// resolv.conf nameserver is an IP address served on the standard port
+	host, port, err := net.SplitHostPort(c.LocalDNSServerAddress)
+	if err != nil || net.ParseIP(host) == nil || port != "53" {
+		problems = append(problems, fmt.Sprintf("LocalDNSServerAddress should be ip:53 to manage resolv.conf, got %q", c.LocalDNSServerAddress))
+	}
+	if c.ResolvConfPath == "" {
+		problems = append(problems, "no ResolvConfPath specified")
+	}
+	return problems
+}
+
+func (c *Config) validateLocalDNS() (problems []string) {
+	if !c.LocalDNSServerRequired() {
+		return nil
+	}
+	if c.LocalDNSCacheEnabled {
+		if c.LocalDNSCacheSize < 1 {
+			problems = append(problems, fmt.Sprintf("LocalDNSCacheSize should be at least 1, got %v", c.LocalDNSCacheSize))
+		}
+		if c.LocalDNSCacheMaxTTL <= 0 {
+			problems = append(problems, fmt.Sprintf("LocalDNSCacheMaxTTL should be positive, got %v", c.LocalDNSCacheMaxTTL))
+		}
+	}
+	if c.LocalDNSUpstreamMode != localdns.UpstreamFanout && c.LocalDNSUpstreamMode != localdns.UpstreamSequential {
+		problems = append(problems, fmt.Sprintf("LocalDNSUpstreamMode should be one of fanout and sequential, got %q", c.LocalDNSUpstreamMode))
+	}
+	if c.LocalDNSUpstreamTimeout <= 0 {
+		problems = append(problems, fmt.Sprintf("LocalDNSUpstreamTimeout should be positive, got %v", c.LocalDNSUpstreamTimeout))
+	}
+	if c.LocalDNSBufferSize < dns.MinMsgSize {
+		problems = append(problems, fmt.Sprintf("LocalDNSBufferSize should be at least %d, got %v", dns.MinMsgSize, c.LocalDNSBufferSize))
+	}
+	if _, err := localdns.NewRewriteHandler(c.LocalDNSRewrites...); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if c.LocalDNSQueryLogEnabled {
+		if c.LocalDNSQueryLogSampleRate < 0 || c.LocalDNSQueryLogSampleRate > 1 {
+			problems = append(problems, fmt.Sprintf("LocalDNSQueryLogSampleRate should be in [0, 1], got %v", c.LocalDNSQueryLogSampleRate))
+		}
+		if c.LocalDNSQueryLogRateLimit < 0 {
+			problems = append(problems, fmt.Sprintf("LocalDNSQueryLogRateLimit should not be negative, got %v", c.LocalDNSQueryLogRateLimit))
+		}
+	}
+	return problems
+}
+
+func (c *Config) validateHooks() (problems []string) {
+	if c.HooksFailurePolicy != HooksFailurePolicyIgnore && c.HooksFailurePolicy != HooksFailurePolicyExit {
+		problems = append(problems, fmt.Sprintf("HooksFailurePolicy should be one of ignore and exit, got %q", c.HooksFailurePolicy))
+	}
+	return problems
+}
+
+func (c *Config) validateWebhooks() (problems []string) {
+	if len(c.Webhooks) == 0 {
+		return nil
+	}
+	for _, webhook := range c.Webhooks {
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("Webhook %q should be http://host or https://host URL", webhook))
+		}
+	}
+	if c.WebhookMaxAttempts < 1 {
+		problems = append(problems, fmt.Sprintf("WebhookMaxAttempts should be at least 1, got %v", c.WebhookMaxAttempts))
+	}
+	if c.WebhookQueueSize < 1 {
+		problems = append(problems, fmt.Sprintf("WebhookQueueSize should be at least 1, got %v", c.WebhookQueueSize))
+	}
+	return problems
+}
+
+func (c *Config) validateConnectTo() (problems []string) {
+	for i := range c.ConnectTo {
+		u := &c.ConnectTo[i]
+		switch {
+		case u.Scheme == "unix" && u.Path != "":
+		case u.Scheme == "tcp" && u.Host != "":
+		default:
+			problems = append(problems, fmt.Sprintf("ConnectTo %q should be unix:///path or tcp://host:port URL", u.String()))
+		}
+	}
+	return problems
+}
+
+func (c *Config) validateNetworkServices() (problems []string) {
+	if c.Mechanism != "" && !supportedMechanisms[strings.ToUpper(c.Mechanism)] {
+		problems = append(problems, fmt.Sprintf("unsupported default mechanism %q", c.Mechanism))
+	}
+
+	interfaces := make(map[string]string)
+	for i := range c.NetworkServices {
+		name := c.NetworkServices[i].String()
+		service, err := c.service(&c.NetworkServices[i])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err.Error()))
+			continue
+		}
+		for _, problem := range service.validate() {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, problem))
+		}
+		for _, problem := range c.validateOverrides(service) {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, problem))
+		}
+
+		ifName := (*nsurl.NSURL)(service.URL).Mechanism().GetParameters()[common.InterfaceNameKey]
+		if ifName == "" {
+			continue
+		}
+		if other, ok := interfaces[ifName]; ok {
+			problems = append(problems, fmt.Sprintf("%s: interface name %q is already used by %s", name, ifName, other))
+			continue
+		}
+		interfaces[ifName] = name
+	}
+	return problems
+}
+
+// validateOverrides - checks the values overridden for the Network Service, the global values are checked separately
+func (c *Config) validateOverrides(s *NetworkService) (problems []string) {
+	positive := func(name string, d, global time.Duration) {
+		if d <= 0 && d != global {
+			problems = append(problems, fmt.Sprintf("%s should be positive, got %v", name, d))
+		}
+	}
+	positive(RequestTimeoutParam, s.RequestTimeout, c.RequestTimeout)
+	if s.LivenessCheckEnabled {
+		positive(LivenessCheckIntervalParam, s.LivenessCheckInterval, c.LivenessCheckInterval)
+		positive(LivenessCheckTimeoutParam, s.LivenessCheckTimeout, c.LivenessCheckTimeout)
+	}
+	return problems
+}
+
+func (s *NetworkService) validate() (problems []string) {
+	u := (*nsurl.NSURL)(s.URL)
+	if u.NetworkService() == "" {
+		problems = append(problems, "no network service name specified")
+	}
+
+	mechanism := u.Mechanism()
+	if !supportedMechanisms[mechanism.GetType()] {
+		problems = append(problems, fmt.Sprintf("unsupported mechanism %q", s.URL.Scheme))
+	}
+
+	switch mechanism.GetType() {
+	case kernelmech.MECHANISM:
+		if ifName := mechanism.GetParameters()[common.InterfaceNameKey]; ifName != "" {
+			if problem := validateInterfaceName(ifName); problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+	case vfiomech.MECHANISM:
+		if s.label(sriovTokenLabel) == "" {
+			problems = append(problems, fmt.Sprintf("%s label is required for %s mechanism", sriovTokenLabel, vfiomech.MECHANISM))
+		}
+	}
+	return problems
+}
+
+// label - returns the value of the label in the request, the URL labels take precedence over the client labels the
+// same way as in connection.WithLabels
+func (s *NetworkService) label(key string) string {
+	if value, ok := (*nsurl.NSURL)(s.URL).Labels()[key]; ok {
+		return value
+	}
+	return s.Labels[key]
+}
+
+// validateInterfaceName - checks the kernel interface name the same way as the kernel dev_valid_name does
+func validateInterfaceName(ifName string) string {
+	if len(ifName) >= ifNameSize {
+		return fmt.Sprintf("interface name %q is longer than %d characters", ifName, ifNameSize-1)
+	}
+	if ifName == "." || ifName == ".." {
+		return fmt.Sprintf("invalid interface name %q", ifName)
+	}
+	for _, r := range ifName {
+		if r == '/' || r == ':' || r <= ' ' || r > '~' {
+			return fmt.Sprintf("interface name %q contains invalid character %q", ifName, r)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// EventType - type of the connection lifecycle event
+type EventType string
+
+const (
+	// EventRequested - the Network Service is being requested
+	EventRequested EventType = "requested"
+	// EventEstablished - the connection is established
+	EventEstablished EventType = "established"
+	// EventUpdated - the connection context of the established connection is changed
+	EventUpdated EventType = "updated"
+	// EventDown - the established connection is reported DOWN by NSMgr
+	EventDown EventType = "down"
+	// EventHealing - the heal client starts healing the connection reported DOWN, it follows EventDown
+	EventHealing EventType = "healing"
+	// EventHealed - the connection reported DOWN is UP again
+	EventHealed EventType = "healed"
+	// EventReselected - the connection reported DOWN is UP again with another NSE
+	EventReselected EventType = "reselected"
+	// EventMonitorReconnected - the NSMgr monitor stream of the established connection is opened again after it broke
+	EventMonitorReconnected EventType = "monitorReconnected"
+	// EventClosed - the connection is closed
+	EventClosed EventType = "closed"
+)
+
+const eventBufferSize = 32
+
+// Event - connection lifecycle event
+type Event struct {
+	Type EventType
+	// ID - connection ID
+	ID string
+	// NetworkService - Network Service URL
+	NetworkService string
+	// Connection - connection, nil for EventRequested
+	Connection *networkservice.Connection
+}
+
+// EventBroker - delivers connection lifecycle events to the subscribers
+type EventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewEventBroker - creates an EventBroker
+func NewEventBroker() *EventBroker {
+	return &EventBroker{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe - returns a channel receiving the events until ctx is done, the channel is closed after that
+func (b *EventBroker) Subscribe(ctx context.Context) <-chan Event {
+	ch := make(chan Event, eventBufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+
+	return ch
+}
+
+// Publish - sends the event to all subscribers, the event is dropped for the subscribers not keeping up
+func (b *EventBroker) Publish(ctx context.Context, event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.FromContext(ctx).Warnf("event %s for %s is dropped for a slow subscriber", event.Type, event.ID)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsurl"
+)
+
+// Log field names of the connection lifecycle log lines, they are kept stable to query the logs by them
+const (
+	// LogFieldConnectionID - connection ID
+	LogFieldConnectionID = "connectionId"
+	// LogFieldNetworkService - Network Service name
+	LogFieldNetworkService = "networkService"
+	// LogFieldNetworkServiceEndpoint - NSE name
+	LogFieldNetworkServiceEndpoint = "networkServiceEndpoint"
+	// LogFieldAttempt - request attempt number starting from 1
+	LogFieldAttempt = "attempt"
+)
+
+// logger - returns the logger from ctx with the connection ID and the Network Service fields
+func (m *Manager) logger(ctx context.Context) log.Logger {
+	return log.FromContext(ctx).
+		WithField(LogFieldConnectionID, m.id).
+		WithField(LogFieldNetworkService, (*nsurl.NSURL)(m.networkService).NetworkService())
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connection - manages the lifecycle of the connections to the Network Services requested by nsc
+package connection
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+)
+
+// Manager - requests a connection to a single Network Service, retries failed requests, holds the established
+// connection and closes it on Stop
+type Manager struct {
+	client         networkservice.NetworkServiceClient
+	id             string
+	networkService *url.URL
+
+	monitorClient        networkservice.MonitorConnectionClient
+	labels               map[string]string
+	broker               *EventBroker
+	store                Store
+	requestTimeout       time.Duration
+	backoff              backoff.Backoff
+	maxAttempts          int
+	budget               *backoff.Budget
+	livenessCheck        LivenessCheck
+	livenessCheckTimeout time.Duration
+
+	mu            sync.Mutex
+	conn          *networkservice.Connection
+	state         networkservice.State
+	monitored     *networkservice.Connection
+	cancelMonitor context.CancelFunc
+}
+
+// NewManager - creates a Manager for the networkService, id is used as a connection ID
+func NewManager(client networkservice.NetworkServiceClient, id string, networkService *url.URL, opts ...Option) *Manager {
+	m := &Manager{
+		client:         client,
+		id:             id,
+		networkService: networkService,
+		requestTimeout: 15 * time.Second,
+		backoff: backoff.Backoff{
+			InitialDelay: 100 * time.Millisecond,
+			MaxDelay:     5 * time.Second,
+			Multiplier:   2,
+			Jitter:       0.2,
+		},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// ID - returns the connection ID
+func (m *Manager) ID() string {
+	return m.id
+}
+
+// NetworkService - returns the Network Service URL
+func (m *Manager) NetworkService() *url.URL {
+	return m.networkService
+}
+
+// Connection - returns the established connection or nil
+func (m *Manager) Connection() *networkservice.Connection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.conn
+}
+
+// Ready - returns true if the connection is established and is not reported DOWN
+func (m *Manager) Ready() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.conn != nil && m.state != networkservice.State_DOWN
+}
+
+// Start - requests the Network Service until it succeeds, ctx is done or the retry budget is exhausted
+func (m *Manager) Start(ctx context.Context) (err error) {
+	logger := m.logger(ctx)
+
+	// The monitor lives until Stop, so the NSMgr view of the connection stays accurate
+	monitorCtx, cancelMonitor := context.WithCancel(context.WithoutCancel(ctx))
+	m.mu.Lock()
+	m.cancelMonitor = cancelMonitor
+	m.mu.Unlock()
+	synced := make(chan struct{})
+	go m.monitor(monitorCtx, synced)
+	defer func() {
+		if err != nil {
+			cancelMonitor()
+		}
+	}()
+
+	syncCtx, cancelSync := context.WithTimeout(ctx, m.requestTimeout)
+	select {
+	case <-synced:
+	case <-syncCtx.Done():
+		logger.Errorf("failed to receive connection state from monitor: %v", syncCtx.Err())
+	}
+	cancelSync()
+
+	retryBackoff := m.backoff
+	attempts := backoff.NewBudget(m.maxAttempts)
+
+	m.publish(ctx, EventRequested, nil)
+
+	for attempt := 1; ; attempt++ {
+		if err = attempts.Take(); err != nil {
+			return errors.Wrapf(err, "no attempts left for the network service after %d attempts", attempt-1)
+		}
+		if err = m.budget.Take(); err != nil {
+			return errors.Wrap(err, "no attempts left for all network services")
+		}
+
+		// Construct a request, the stored connection is tried on the first attempt only
+		request := m.constructRequest(ctx, attempt == 1)
+
+		var conn *networkservice.Connection
+		conn, err = m.request(ctx, request)
+		if err == nil {
+			m.mu.Lock()
+			m.conn = conn
+			m.state = networkservice.State_UP
+			m.mu.Unlock()
+			m.storeConnection(ctx, conn)
+
+			logger.WithField(LogFieldNetworkServiceEndpoint, conn.GetNetworkServiceEndpointName()).
+				WithField(LogFieldAttempt, attempt).
+				Infof("successfully connected to %v. Request: %v. Response: %v", conn.GetNetworkService(), request, conn)
+			m.publish(ctx, EventEstablished, conn)
+			return nil
+		}
+		logger.WithField(LogFieldAttempt, attempt).Errorf("failed connect to NSMgr (attempt %d): %v", attempt, err.Error())
+
+		if waitErr := retryBackoff.Wait(ctx); waitErr != nil {
+			return errors.Wrap(err, "request cancelled")
+		}
+	}
+}
+
+// Stop - closes the established connection if any
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	conn := m.conn
+	m.conn = nil
+	if m.cancelMonitor != nil {
+		m.cancelMonitor()
+		m.cancelMonitor = nil
+	}
+	m.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+
+	closeCtx, cancelClose := context.WithTimeout(ctx, m.requestTimeout)
+	defer cancelClose()
+
+	_, err := m.client.Close(closeCtx, conn)
+	m.deleteStoredConnection(ctx)
+	m.publish(ctx, EventClosed, conn)
+	return errors.Wrapf(err, "failed to close connection %s", conn.GetId())
+}
+
+func (m *Manager) publish(ctx context.Context, eventType EventType, conn *networkservice.Connection) {
+	m.broker.Publish(ctx, Event{
+		Type:           eventType,
+		ID:             m.id,
+		NetworkService: m.networkService.String(),
+		Connection:     conn,
+	})
+}
+
+func (m *Manager) request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	requestCtx, cancelRequest := context.WithTimeout(ctx, m.requestTimeout)
+	defer cancelRequest()
+
+	return m.client.Request(requestCtx, request)
+}
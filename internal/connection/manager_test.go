@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection_test
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/state"
+)
+
+type fakeClient struct {
+	mu          sync.Mutex
+	failures    int
+	requests    []*networkservice.NetworkServiceRequest
+	requestCtxs []context.Context
+	closes      []*networkservice.Connection
+}
+
+func (c *fakeClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, _ ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests = append(c.requests, request)
+	c.requestCtxs = append(c.requestCtxs, ctx)
+	if c.failures != 0 {
+		c.failures--
+		return nil, errors.New("endpoint not found")
+	}
+	return request.GetConnection(), nil
+}
+
+func (c *fakeClient) Close(_ context.Context, conn *networkservice.Connection, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closes = append(c.closes, conn)
+	return new(emptypb.Empty), nil
+}
+
+func testBackoff() backoff.Backoff {
+	return backoff.Backoff{
+		InitialDelay: time.Millisecond,
+		MaxDelay:     time.Millisecond,
+	}
+}
+
+func TestManager_RetriesUntilSuccess(t *testing.T) {
+	client := &fakeClient{failures: 2}
+	u, err := url.Parse("kernel://my-service/nsm-1")
+	require.NoError(t, err)
+
+	m := connection.NewManager(client, "nsc-0", u, connection.WithBackoff(testBackoff()))
+	require.NoError(t, m.Start(context.Background()))
+
+	require.Len(t, client.requests, 3)
+	for _, ctx := range client.requestCtxs {
+		require.Error(t, ctx.Err())
+	}
+	require.Equal(t, "nsc-0", m.Connection().GetId())
+	require.Equal(t, "my-service", m.Connection().GetNetworkService())
+
+	require.NoError(t, m.Stop(context.Background()))
+	require.NoError(t, m.Stop(context.Background()))
+	require.Len(t, client.closes, 1)
+	require.Nil(t, m.Connection())
+}
+
+func TestManager_MaxAttempts(t *testing.T) {
+	client := &fakeClient{failures: -1}
+	u, err := url.Parse("kernel://my-service/nsm-1")
+	require.NoError(t, err)
+
+	m := connection.NewManager(client, "nsc-0", u,
+		connection.WithBackoff(testBackoff()),
+		connection.WithMaxAttempts(2),
+	)
+	require.ErrorIs(t, m.Start(context.Background()), backoff.ErrBudgetExhausted)
+	require.Len(t, client.requests, 2)
+
+	require.NoError(t, m.Stop(context.Background()))
+	require.Empty(t, client.closes)
+}
+
+func TestManager_SharedBudget(t *testing.T) {
+	client := &fakeClient{failures: -1}
+	budget := backoff.NewBudget(3)
+
+	u, err := url.Parse("kernel://my-service/nsm-1")
+	require.NoError(t, err)
+
+	m1 := connection.NewManager(client, "nsc-0", u, connection.WithBackoff(testBackoff()), connection.WithBudget(budget))
+	m2 := connection.NewManager(client, "nsc-1", u, connection.WithBackoff(testBackoff()), connection.WithBudget(budget))
+
+	require.ErrorIs(t, m1.Start(context.Background()), backoff.ErrBudgetExhausted)
+	require.ErrorIs(t, m2.Start(context.Background()), backoff.ErrBudgetExhausted)
+	require.Len(t, client.requests, 3)
+}
+
+func TestManager_StartCancelled(t *testing.T) {
+	client := &fakeClient{failures: -1}
+	u, err := url.Parse("kernel://my-service/nsm-1")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	m := connection.NewManager(client, "nsc-0", u, connection.WithBackoff(testBackoff()))
+	require.Error(t, m.Start(ctx))
+	require.Nil(t, m.Connection())
+}
+
+func TestManager_Labels(t *testing.T) {
+	client := &fakeClient{}
+	u, err := url.Parse("kernel://my-service/nsm-1?app=vpn")
+	require.NoError(t, err)
+
+	m := connection.NewManager(client, "nsc-0", u, connection.WithLabels(map[string]string{
+		"app":  "nsc",
+		"zone": "a",
+	}))
+	require.NoError(t, m.Start(context.Background()))
+
+	require.Equal(t, map[string]string{"app": "vpn", "zone": "a"}, m.Connection().GetLabels())
+}
+
+func TestManager_Store(t *testing.T) {
+	store, err := state.NewFile(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(t, err)
+	require.NoError(t, store.Save("nsc-0", &networkservice.Connection{
+		Id:                         "nsc-0",
+		NetworkService:             "my-service",
+		NetworkServiceEndpointName: "nse-1",
+		Mechanism:                  &networkservice.Mechanism{Type: "KERNEL"},
+	}))
+
+	client := &fakeClient{failures: 1}
+	u, err := url.Parse("kernel://my-service/nsm-1")
+	require.NoError(t, err)
+
+	m := connection.NewManager(client, "nsc-0", u, connection.WithBackoff(testBackoff()), connection.WithStore(store))
+	require.NoError(t, m.Start(context.Background()))
+
+	// The stored connection is requested on the first attempt only
+	require.Len(t, client.requests, 2)
+	require.Equal(t, "nse-1", client.requests[0].GetConnection().GetNetworkServiceEndpointName())
+	require.Empty(t, client.requests[1].GetConnection().GetNetworkServiceEndpointName())
+	require.Equal(t, "nsc-0", store.Load("nsc-0").GetId())
+
+	require.NoError(t, m.Stop(context.Background()))
+	require.Nil(t, store.Load("nsc-0"))
+}
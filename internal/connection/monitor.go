@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// monitor - keeps the NSMgr view of the connection up to date until ctx is done, the stream is opened again with
+// backoff if it breaks. synced is closed once the initial state is received or the monitor is not available.
+func (m *Manager) monitor(ctx context.Context, synced chan struct{}) {
+	var syncOnce sync.Once
+	markSynced := func() { syncOnce.Do(func() { close(synced) }) }
+	defer markSynced()
+
+	if m.monitorClient == nil {
+		return
+	}
+	logger := m.logger(ctx)
+
+	retryBackoff := m.backoff
+	reopened := false
+	for {
+		err := m.monitorStream(ctx, func() {
+			retryBackoff.Reset()
+			markSynced()
+			// The connection may be not requested yet when the stream is opened first
+			if conn := m.Connection(); reopened && conn != nil {
+				m.publish(ctx, EventMonitorReconnected, conn)
+			}
+			reopened = true
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warnf("failed to monitor connection: %v", err.Error())
+		// The request doesn't wait for the monitor longer than needed
+		markSynced()
+		if retryBackoff.Wait(ctx) != nil {
+			return
+		}
+	}
+}
+
+// monitorStream - receives the connection events until the stream breaks, onSync is called after the initial event
+func (m *Manager) monitorStream(ctx context.Context, onSync func()) error {
+	stream, err := m.monitorClient.MonitorConnections(ctx, &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{
+			{
+				Id: m.id,
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error from monitorConnectionClient")
+	}
+
+	for initial := true; ; initial = false {
+		event, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "error from monitorConnection stream")
+		}
+
+		var monitored *networkservice.Connection
+		for _, conn := range event.GetConnections() {
+			if segments := conn.GetPath().GetPathSegments(); len(segments) != 0 && segments[0].GetId() == m.id {
+				monitored = conn
+			}
+		}
+		if monitored != nil && event.GetType() == networkservice.ConnectionEventType_DELETE {
+			monitored.State = networkservice.State_DOWN
+		}
+
+		switch {
+		case monitored != nil:
+			m.setMonitored(monitored)
+			m.setState(ctx, monitored.GetState(), monitored)
+		case initial:
+			// NSMgr doesn't know the connection, for example after its restart
+			m.setMonitored(nil)
+			m.setState(ctx, networkservice.State_DOWN, nil)
+		}
+		if initial {
+			onSync()
+		}
+	}
+}
+
+func (m *Manager) setMonitored(conn *networkservice.Connection) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.monitored = conn
+}
+
+func (m *Manager) monitoredConnection() *networkservice.Connection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.monitored == nil {
+		return nil
+	}
+	return m.monitored.Clone()
+}
+
+func (m *Manager) setState(ctx context.Context, state networkservice.State, monitored *networkservice.Connection) {
+	m.mu.Lock()
+	conn, prev := m.conn, m.state
+	if conn == nil {
+		m.mu.Unlock()
+		return
+	}
+	reselected := state != networkservice.State_DOWN && prev == networkservice.State_DOWN &&
+		monitored.GetNetworkServiceEndpointName() != conn.GetNetworkServiceEndpointName()
+	updated := state != networkservice.State_DOWN && monitored.GetContext() != nil &&
+		!proto.Equal(monitored.GetContext(), conn.GetContext())
+	if prev == state && !updated {
+		m.mu.Unlock()
+		return
+	}
+	m.state = state
+	if reselected || updated {
+		conn = conn.Clone()
+		conn.NetworkServiceEndpointName = monitored.GetNetworkServiceEndpointName()
+		conn.Context = monitored.GetContext()
+		m.conn = conn
+	}
+	m.mu.Unlock()
+
+	if reselected || updated {
+		m.storeConnection(ctx, conn)
+	}
+
+	logger := m.logger(ctx).WithField(LogFieldNetworkServiceEndpoint, conn.GetNetworkServiceEndpointName())
+	switch {
+	case state == networkservice.State_DOWN:
+		logger.Warnf("connection %s to %v is down", m.id, conn.GetNetworkService())
+		m.publish(ctx, EventDown, conn)
+		// The heal client starts healing on the same monitor event
+		logger.Infof("healing connection %s to %v", m.id, conn.GetNetworkService())
+		m.publish(ctx, EventHealing, conn)
+	case reselected:
+		logger.Infof("connection %s to %v is reselected to %v", m.id, conn.GetNetworkService(), conn.GetNetworkServiceEndpointName())
+		m.publish(ctx, EventReselected, conn)
+	case prev == networkservice.State_DOWN:
+		logger.Infof("connection %s to %v is healed", m.id, conn.GetNetworkService())
+		m.publish(ctx, EventHealed, conn)
+	default:
+		m.publish(ctx, EventUpdated, conn)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection_test
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+)
+
+type fakeMonitorClient struct {
+	events   chan *networkservice.ConnectionEvent
+	failures atomic.Int32
+}
+
+func (c *fakeMonitorClient) MonitorConnections(ctx context.Context, _ *networkservice.MonitorScopeSelector, _ ...grpc.CallOption) (networkservice.MonitorConnection_MonitorConnectionsClient, error) {
+	if c.failures.Add(-1) >= 0 {
+		return nil, errors.New("NSMgr is not available")
+	}
+	return &fakeMonitorStream{ctx: ctx, events: c.events}, nil
+}
+
+type fakeMonitorStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events <-chan *networkservice.ConnectionEvent
+}
+
+func (s *fakeMonitorStream) Recv() (*networkservice.ConnectionEvent, error) {
+	select {
+	case event := <-s.events:
+		// nil event breaks the stream
+		if event == nil {
+			return nil, errors.New("stream is broken")
+		}
+		return event, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func monitorEvent(eventType networkservice.ConnectionEventType, state networkservice.State) *networkservice.ConnectionEvent {
+	return &networkservice.ConnectionEvent{
+		Type: eventType,
+		Connections: map[string]*networkservice.Connection{
+			"nsc-0": {
+				Id:             "nsc-0",
+				NetworkService: "my-service",
+				State:          state,
+				Path: &networkservice.Path{
+					Index:        1,
+					PathSegments: []*networkservice.PathSegment{{Id: "nsc-0"}, {Id: "nsmgr-0"}},
+				},
+			},
+		},
+	}
+}
+
+func TestManager_Monitor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := connection.NewEventBroker()
+	events := broker.Subscribe(ctx)
+	monitorClient := &fakeMonitorClient{
+		events: make(chan *networkservice.ConnectionEvent, 10),
+	}
+	monitorClient.events <- &networkservice.ConnectionEvent{Type: networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER}
+
+	u, err := url.Parse("kernel://my-service/nsm-1")
+	require.NoError(t, err)
+	m := connection.NewManager(&fakeClient{}, "nsc-0", u,
+		connection.WithBackoff(testBackoff()),
+		connection.WithMonitorClient(monitorClient),
+		connection.WithEventBroker(broker))
+	require.NoError(t, m.Start(ctx))
+	require.True(t, m.Ready())
+
+	nextEvent := func() connection.EventType {
+		select {
+		case event := <-events:
+			return event.Type
+		case <-time.After(time.Second):
+			return ""
+		}
+	}
+	require.Equal(t, connection.EventRequested, nextEvent())
+	require.Equal(t, connection.EventEstablished, nextEvent())
+
+	monitorClient.events <- monitorEvent(networkservice.ConnectionEventType_UPDATE, networkservice.State_DOWN)
+	require.Equal(t, connection.EventDown, nextEvent())
+	require.Equal(t, connection.EventHealing, nextEvent())
+	require.False(t, m.Ready())
+
+	monitorClient.events <- monitorEvent(networkservice.ConnectionEventType_UPDATE, networkservice.State_UP)
+	require.Equal(t, connection.EventHealed, nextEvent())
+	require.True(t, m.Ready())
+
+	require.NoError(t, m.Stop(ctx))
+	require.Equal(t, connection.EventClosed, nextEvent())
+}
+
+func TestManager_MonitorReconnected(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := connection.NewEventBroker()
+	events := broker.Subscribe(ctx)
+	monitorClient := &fakeMonitorClient{
+		events: make(chan *networkservice.ConnectionEvent, 10),
+	}
+	monitorClient.events <- &networkservice.ConnectionEvent{Type: networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER}
+
+	u, err := url.Parse("kernel://my-service/nsm-1")
+	require.NoError(t, err)
+	m := connection.NewManager(&fakeClient{}, "nsc-0", u,
+		connection.WithBackoff(testBackoff()),
+		connection.WithMonitorClient(monitorClient),
+		connection.WithEventBroker(broker))
+	require.NoError(t, m.Start(ctx))
+
+	nextEvent := func() connection.Event {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(time.Second):
+			return connection.Event{}
+		}
+	}
+	require.Equal(t, connection.EventRequested, nextEvent().Type)
+	require.Equal(t, connection.EventEstablished, nextEvent().Type)
+
+	// The failed attempts to open the stream again are not reported
+	monitorClient.failures.Store(2)
+	monitorClient.events <- nil
+	monitorClient.events <- monitorEvent(networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, networkservice.State_UP)
+	event := nextEvent()
+	require.Equal(t, connection.EventMonitorReconnected, event.Type)
+	require.NotNil(t, event.Connection)
+	require.Equal(t, int32(-1), monitorClient.failures.Load())
+
+	require.NoError(t, m.Stop(ctx))
+	require.Equal(t, connection.EventClosed, nextEvent().Type)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+)
+
+// LivenessCheck - checks if the dataplane of the connection is alive
+type LivenessCheck func(ctx context.Context, conn *networkservice.Connection) bool
+
+// Option - Manager option
+type Option func(m *Manager)
+
+// PoolOption - Pool option
+type PoolOption func(p *Pool)
+
+// WithRelease - sets a function releasing the resources created by ManagerFactory for the connection ID, it is called
+// once the manager is stopped and removed from the pool
+func WithRelease(release func(id string)) PoolOption {
+	return func(p *Pool) {
+		p.release = release
+	}
+}
+
+// WithMaxTotalAttempts - sets a maximum number of request attempts shared by the Network Services of an Update, 0
+// means no limit. Each Update and Add gets a new retry budget, so the exhausted one doesn't affect the next reload.
+func WithMaxTotalAttempts(maxTotalAttempts int) PoolOption {
+	return func(p *Pool) {
+		p.maxTotalAttempts = maxTotalAttempts
+	}
+}
+
+// WithMonitorClient - sets a client following the connection state in NSMgr, it is used to restore the connection
+// after the client restart and to report the connection changes
+func WithMonitorClient(monitorClient networkservice.MonitorConnectionClient) Option {
+	return func(m *Manager) {
+		m.monitorClient = monitorClient
+	}
+}
+
+// WithRequestTimeout - sets a timeout for each Request and Close
+func WithRequestTimeout(requestTimeout time.Duration) Option {
+	return func(m *Manager) {
+		m.requestTimeout = requestTimeout
+	}
+}
+
+// WithBackoff - sets a backoff between failed requests
+func WithBackoff(retryBackoff backoff.Backoff) Option {
+	return func(m *Manager) {
+		m.backoff = retryBackoff
+	}
+}
+
+// WithMaxAttempts - sets a maximum number of request attempts, 0 means no limit
+func WithMaxAttempts(maxAttempts int) Option {
+	return func(m *Manager) {
+		m.maxAttempts = maxAttempts
+	}
+}
+
+// WithBudget - sets a retry budget shared with other managers, Pool sets it per Update
+func WithBudget(budget *backoff.Budget) Option {
+	return func(m *Manager) {
+		m.budget = budget
+	}
+}
+
+// WithLivenessCheck - sets a liveness check used to decide if a restored DOWN connection should be reselected
+func WithLivenessCheck(livenessCheck LivenessCheck, timeout time.Duration) Option {
+	return func(m *Manager) {
+		m.livenessCheck = livenessCheck
+		m.livenessCheckTimeout = timeout
+	}
+}
+
+// WithLabels - sets client labels merged into every request, the Network Service URL labels take precedence
+func WithLabels(labels map[string]string) Option {
+	return func(m *Manager) {
+		m.labels = labels
+	}
+}
+
+// WithEventBroker - sets a broker receiving the connection lifecycle events
+func WithEventBroker(broker *EventBroker) Option {
+	return func(m *Manager) {
+		m.broker = broker
+	}
+}
+
+// WithStore - sets a store of the established connections, the stored connection is requested again after the
+// client restart if NSMgr monitor doesn't report it
+func WithStore(store Store) Option {
+	return func(m *Manager) {
+		m.store = store
+	}
+}
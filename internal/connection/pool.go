@@ -0,0 +1,309 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+	"github.com/networkservicemesh/cmd-nsc/internal/config"
+)
+
+const dynamicKeyPrefix = "dynamic#"
+
+// ErrNotFound - returned by Pool.Remove when there is no connection with the ID
+var ErrNotFound = errors.New("not found")
+
+// ManagerFactory - creates a Manager for the Network Service with the connection ID
+type ManagerFactory func(id string, service *config.NetworkService) *Manager
+
+type poolEntry struct {
+	key     string
+	id      string
+	dynamic bool
+	service *config.NetworkService
+	manager *Manager
+
+	cancelStart context.CancelFunc
+	started     chan struct{}
+	err         error
+}
+
+// Pool - set of managers, one per Network Service, updated with the changes of the configuration
+type Pool struct {
+	name       string
+	newManager ManagerFactory
+	release    func(id string)
+	sem        chan struct{}
+
+	maxTotalAttempts int
+
+	mu        sync.Mutex
+	entries   map[string]*poolEntry
+	nextIndex int
+}
+
+// NewPool - creates a Pool, name is used as a prefix of the connection IDs, at most concurrency Network Services
+// are requested concurrently, 0 means no limit
+func NewPool(name string, newManager ManagerFactory, concurrency int, opts ...PoolOption) *Pool {
+	p := &Pool{
+		name:       name,
+		newManager: newManager,
+		release:    func(string) {},
+		entries:    make(map[string]*poolEntry),
+	}
+	if concurrency > 0 {
+		p.sem = make(chan struct{}, concurrency)
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Update - closes the connections to the removed Network Services, requests the new ones and re-requests the changed
+// ones and the ones failed to connect. The unchanged connections are not touched. Returns when all requests are completed. Failures are reported
+// per Network Service. The requests of the Update share a new retry budget of WithMaxTotalAttempts, if any manager
+// exhausts it, the rest are cancelled and backoff.ErrBudgetExhausted is returned.
+func (p *Pool) Update(ctx context.Context, services []*config.NetworkService) error {
+	keys := serviceKeys(services)
+	indexes := make(map[string]int, len(keys))
+	for i, key := range keys {
+		indexes[key] = i
+	}
+
+	p.mu.Lock()
+	var stale []*poolEntry
+	for key, e := range p.entries {
+		if e.dynamic {
+			continue
+		}
+		if i, ok := indexes[key]; !ok || !reflect.DeepEqual(e.service, services[i]) || e.failed() {
+			e.cancelStart()
+			stale = append(stale, e)
+			delete(p.entries, key)
+		}
+	}
+	p.mu.Unlock()
+
+	// The changed Network Services keep their connection IDs
+	ids := make(map[string]string)
+	for _, e := range stale {
+		if err := p.stop(ctx, e); err != nil {
+			e.manager.logger(ctx).Errorf("failed to close %v: %v", e.manager.NetworkService().String(), err.Error())
+		}
+		if _, ok := indexes[e.key]; ok {
+			ids[e.key] = e.id
+		}
+	}
+
+	startCtx, cancelStart := context.WithCancel(ctx)
+	defer cancelStart()
+
+	budget := backoff.NewBudget(p.maxTotalAttempts)
+	var added []*poolEntry
+	p.mu.Lock()
+	for i, key := range keys {
+		if _, ok := p.entries[key]; ok {
+			continue
+		}
+		id, ok := ids[key]
+		if !ok {
+			id = fmt.Sprintf("%s-%d", p.name, p.nextIndex)
+			p.nextIndex++
+		}
+		e := &poolEntry{
+			key:     key,
+			id:      id,
+			service: services[i],
+			manager: p.newManager(id, services[i]),
+			started: make(chan struct{}),
+		}
+		WithBudget(budget)(e.manager)
+		var entryCtx context.Context
+		entryCtx, e.cancelStart = context.WithCancel(startCtx)
+		p.entries[key] = e
+		added = append(added, e)
+
+		go p.start(entryCtx, e, cancelStart)
+	}
+	p.mu.Unlock()
+
+	var budgetErr error
+	for _, e := range added {
+		<-e.started
+		if budgetErr == nil && errors.Is(e.err, backoff.ErrBudgetExhausted) {
+			budgetErr = e.err
+		}
+	}
+	return budgetErr
+}
+
+// failed - returns true if the request of the entry is completed with an error
+func (e *poolEntry) failed() bool {
+	select {
+	case <-e.started:
+		return e.err != nil
+	default:
+		return false
+	}
+}
+
+func (p *Pool) start(ctx context.Context, e *poolEntry, cancelAll context.CancelFunc) {
+	defer close(e.started)
+	defer e.cancelStart()
+
+	if p.sem != nil {
+		select {
+		case p.sem <- struct{}{}:
+			defer func() { <-p.sem }()
+		case <-ctx.Done():
+			e.err = ctx.Err()
+			e.manager.logger(ctx).Errorf("failed connect to %v: %v", e.manager.NetworkService().String(), e.err)
+			return
+		}
+	}
+
+	if e.err = e.manager.Start(ctx); e.err != nil {
+		e.manager.logger(ctx).Errorf("failed connect to %v: %v", e.manager.NetworkService().String(), e.err.Error())
+		if errors.Is(e.err, backoff.ErrBudgetExhausted) {
+			cancelAll()
+		}
+	}
+}
+
+// Add - requests the Network Service not managed by the configuration, it is not affected by Update. Returns when
+// the request is completed. The request has a retry budget of WithMaxTotalAttempts of its own.
+func (p *Pool) Add(ctx context.Context, service *config.NetworkService) (*Manager, error) {
+	p.mu.Lock()
+	id := fmt.Sprintf("%s-%d", p.name, p.nextIndex)
+	p.nextIndex++
+	e := &poolEntry{
+		key:     dynamicKeyPrefix + id,
+		id:      id,
+		dynamic: true,
+		service: service,
+		manager: p.newManager(id, service),
+		started: make(chan struct{}),
+	}
+	WithBudget(backoff.NewBudget(p.maxTotalAttempts))(e.manager)
+	var entryCtx context.Context
+	entryCtx, e.cancelStart = context.WithCancel(ctx)
+	p.entries[e.key] = e
+	p.mu.Unlock()
+
+	p.start(entryCtx, e, func() {})
+	if e.err != nil {
+		p.mu.Lock()
+		if p.entries[e.key] == e {
+			delete(p.entries, e.key)
+		}
+		p.mu.Unlock()
+		p.release(e.id)
+		return nil, e.err
+	}
+	return e.manager, nil
+}
+
+// Remove - closes the connection with the ID. A connection to the Network Service from the configuration is
+// requested again on the next Update.
+func (p *Pool) Remove(ctx context.Context, id string) error {
+	p.mu.Lock()
+	var found *poolEntry
+	for key, e := range p.entries {
+		if e.id == id {
+			found = e
+			delete(p.entries, key)
+			break
+		}
+	}
+	p.mu.Unlock()
+
+	if found == nil {
+		return errors.Wrapf(ErrNotFound, "connection %s", id)
+	}
+	found.cancelStart()
+	return p.stop(ctx, found)
+}
+
+// Manager - returns the manager of the connection with the ID or nil
+func (p *Pool) Manager(id string) *Manager {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, e := range p.entries {
+		if e.id == id {
+			return e.manager
+		}
+	}
+	return nil
+}
+
+// Stop - cancels pending requests and closes all connections
+func (p *Pool) Stop(ctx context.Context) {
+	p.mu.Lock()
+	entries := p.entries
+	p.entries = make(map[string]*poolEntry)
+	p.mu.Unlock()
+
+	for _, e := range entries {
+		e.cancelStart()
+	}
+	for _, e := range entries {
+		if err := p.stop(ctx, e); err != nil {
+			e.manager.logger(ctx).Errorf("failed to close %v: %v", e.manager.NetworkService().String(), err.Error())
+		}
+	}
+}
+
+// stop - waits for the pending request of the removed entry, closes its connection and releases its resources
+func (p *Pool) stop(ctx context.Context, e *poolEntry) error {
+	<-e.started
+	err := e.manager.Stop(ctx)
+	p.release(e.id)
+	return err
+}
+
+// Managers - returns managers of all Network Services
+func (p *Pool) Managers() []*Manager {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	managers := make([]*Manager, 0, len(p.entries))
+	for _, e := range p.entries {
+		managers = append(managers, e.manager)
+	}
+	return managers
+}
+
+// serviceKeys - returns keys identifying the Network Services regardless of their labels and overrides
+func serviceKeys(services []*config.NetworkService) []string {
+	keys := make([]string, 0, len(services))
+	counts := make(map[string]int, len(services))
+	for _, service := range services {
+		u := *service.URL
+		u.RawQuery = ""
+		keys = append(keys, fmt.Sprintf("%s#%d", u.String(), counts[u.String()]))
+		counts[u.String()]++
+	}
+	return keys
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection_test
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+	"github.com/networkservicemesh/cmd-nsc/internal/config"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+)
+
+func networkService(t *testing.T, rawURL string) *config.NetworkService {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return &config.NetworkService{URL: u}
+}
+
+func connectionIDs(conns ...string) []string {
+	sort.Strings(conns)
+	return conns
+}
+
+func requestedIDs(client *fakeClient, from int) []string {
+	var ids []string
+	for _, request := range client.requests[from:] {
+		ids = append(ids, request.GetConnection().GetId())
+	}
+	return connectionIDs(ids...)
+}
+
+func TestPool_Update(t *testing.T) {
+	client := &fakeClient{}
+	pool := connection.NewPool("nsc", func(id string, service *config.NetworkService) *connection.Manager {
+		return connection.NewManager(client, id, service.URL, connection.WithBackoff(testBackoff()))
+	}, 1)
+
+	require.NoError(t, pool.Update(context.Background(), []*config.NetworkService{
+		networkService(t, "kernel://a/nsm-1"),
+		networkService(t, "kernel://b/nsm-2?app=1"),
+	}))
+	require.Equal(t, connectionIDs("nsc-0", "nsc-1"), requestedIDs(client, 0))
+
+	require.NoError(t, pool.Update(context.Background(), []*config.NetworkService{
+		networkService(t, "kernel://b/nsm-2?app=2"),
+		networkService(t, "kernel://c/nsm-3"),
+	}))
+
+	// a is closed, b is re-requested with the same ID, c is requested with a new ID
+	var closed []string
+	for _, conn := range client.closes {
+		closed = append(closed, conn.GetId())
+	}
+	require.Equal(t, connectionIDs("nsc-0", "nsc-1"), connectionIDs(closed...))
+
+	require.Equal(t, connectionIDs("nsc-1", "nsc-2"), requestedIDs(client, 2))
+
+	// Unchanged Network Services are not touched
+	require.NoError(t, pool.Update(context.Background(), []*config.NetworkService{
+		networkService(t, "kernel://b/nsm-2?app=2"),
+		networkService(t, "kernel://c/nsm-3"),
+	}))
+	require.Len(t, client.requests, 4)
+	require.Len(t, client.closes, 2)
+
+	pool.Stop(context.Background())
+	require.Len(t, client.closes, 4)
+	require.Empty(t, pool.Managers())
+}
+
+// blockingClient - blocks the requests until release is closed and tracks the number of concurrent requests
+type blockingClient struct {
+	fakeClient
+	release chan struct{}
+
+	activeMu  sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (c *blockingClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.activeMu.Lock()
+	c.active++
+	c.maxActive = max(c.maxActive, c.active)
+	c.activeMu.Unlock()
+	defer func() {
+		c.activeMu.Lock()
+		c.active--
+		c.activeMu.Unlock()
+	}()
+
+	select {
+	case <-c.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return c.fakeClient.Request(ctx, request, opts...)
+}
+
+func (c *blockingClient) activeRequests() (active, maxActive int) {
+	c.activeMu.Lock()
+	defer c.activeMu.Unlock()
+	return c.active, c.maxActive
+}
+
+func TestPool_Concurrency(t *testing.T) {
+	client := &blockingClient{release: make(chan struct{})}
+	pool := connection.NewPool("nsc", func(id string, service *config.NetworkService) *connection.Manager {
+		return connection.NewManager(client, id, service.URL, connection.WithBackoff(testBackoff()))
+	}, 2)
+
+	updated := make(chan error, 1)
+	go func() {
+		updated <- pool.Update(context.Background(), []*config.NetworkService{
+			networkService(t, "kernel://a/nsm-1"),
+			networkService(t, "kernel://b/nsm-2"),
+			networkService(t, "kernel://c/nsm-3"),
+			networkService(t, "kernel://d/nsm-4"),
+			networkService(t, "kernel://e/nsm-5"),
+		})
+	}()
+
+	require.Eventually(t, func() bool {
+		active, _ := client.activeRequests()
+		return active == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// The rest of the Network Services wait for a free slot
+	time.Sleep(50 * time.Millisecond)
+	active, maxActive := client.activeRequests()
+	require.Equal(t, 2, active)
+	require.Equal(t, 2, maxActive)
+
+	close(client.release)
+	require.NoError(t, <-updated)
+
+	_, maxActive = client.activeRequests()
+	require.Equal(t, 2, maxActive)
+	require.Len(t, client.requests, 5)
+
+	pool.Stop(context.Background())
+}
+
+func TestPool_Release(t *testing.T) {
+	client := &fakeClient{}
+	var mu sync.Mutex
+	var released []string
+	pool := connection.NewPool("nsc", func(id string, service *config.NetworkService) *connection.Manager {
+		return connection.NewManager(client, id, service.URL, connection.WithBackoff(testBackoff()))
+	}, 0, connection.WithRelease(func(id string) {
+		mu.Lock()
+		defer mu.Unlock()
+		released = append(released, id)
+	}))
+	releasedIDs := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return connectionIDs(append([]string(nil), released...)...)
+	}
+
+	require.NoError(t, pool.Update(context.Background(), []*config.NetworkService{
+		networkService(t, "kernel://a/nsm-1"),
+		networkService(t, "kernel://b/nsm-2"),
+	}))
+	require.Empty(t, releasedIDs())
+
+	require.NoError(t, pool.Update(context.Background(), []*config.NetworkService{
+		networkService(t, "kernel://b/nsm-2"),
+	}))
+	require.Equal(t, []string{"nsc-0"}, releasedIDs())
+
+	_, err := pool.Add(context.Background(), networkService(t, "kernel://c/nsm-3"))
+	require.NoError(t, err)
+	require.NoError(t, pool.Remove(context.Background(), "nsc-1"))
+	require.Equal(t, connectionIDs("nsc-0", "nsc-1"), releasedIDs())
+
+	pool.Stop(context.Background())
+	require.Equal(t, connectionIDs("nsc-0", "nsc-1", "nsc-2"), releasedIDs())
+}
+
+func TestPool_UpdateRetriesFailed(t *testing.T) {
+	client := &fakeClient{failures: -1}
+	pool := connection.NewPool("nsc", func(id string, service *config.NetworkService) *connection.Manager {
+		return connection.NewManager(client, id, service.URL, connection.WithBackoff(testBackoff()))
+	}, 0)
+	services := []*config.NetworkService{
+		networkService(t, "kernel://a/nsm-1"),
+	}
+
+	// The cancelled update leaves the Network Service not connected
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.NoError(t, pool.Update(ctx, services))
+	require.Nil(t, pool.Manager("nsc-0").Connection())
+
+	client.mu.Lock()
+	client.failures = 0
+	client.mu.Unlock()
+
+	// The same configuration requests it again with the same ID
+	require.NoError(t, pool.Update(context.Background(), services))
+	require.Equal(t, "nsc-0", pool.Manager("nsc-0").Connection().GetId())
+
+	pool.Stop(context.Background())
+}
+
+func TestPool_UpdateAfterBudgetExhausted(t *testing.T) {
+	client := &fakeClient{failures: -1}
+	pool := connection.NewPool("nsc", func(id string, service *config.NetworkService) *connection.Manager {
+		return connection.NewManager(client, id, service.URL, connection.WithBackoff(testBackoff()))
+	}, 0, connection.WithMaxTotalAttempts(2))
+	services := []*config.NetworkService{
+		networkService(t, "kernel://a/nsm-1"),
+	}
+
+	require.ErrorIs(t, pool.Update(context.Background(), services), backoff.ErrBudgetExhausted)
+	require.Len(t, client.requests, 2)
+
+	client.mu.Lock()
+	client.failures = 1
+	client.mu.Unlock()
+
+	// The reload has a new budget, the second attempt connects
+	require.NoError(t, pool.Update(context.Background(), services))
+	require.Len(t, client.requests, 4)
+	require.Equal(t, "nsc-0", pool.Manager("nsc-0").Connection().GetId())
+
+	pool.Stop(context.Background())
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020-2022 Doc.ai and/or its affiliates.
+//
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsurl"
+)
+
+func (m *Manager) constructRequest(ctx context.Context, useStored bool) *networkservice.NetworkServiceRequest {
+	u := (*nsurl.NSURL)(m.networkService)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             m.id,
+			NetworkService: u.NetworkService(),
+			Labels:         m.mergeLabels(u.Labels()),
+		},
+		MechanismPreferences: []*networkservice.Mechanism{
+			u.Mechanism(),
+		},
+	}
+
+	// The stored connection is used if monitoring doesn't report the connection, for example after NSMgr restart
+	if conn := m.storedConnection(u.NetworkService(), u.Mechanism().GetType()); useStored && conn != nil {
+		conn.Labels = request.GetConnection().GetLabels()
+		request.Connection = conn
+	}
+
+	// Looking for a match in the connection received from monitoring
+	if conn := m.monitoredConnection(); conn != nil {
+		path := conn.GetPath()
+		if path.GetIndex() == 1 && conn.GetMechanism().GetType() == u.Mechanism().GetType() {
+			request.Connection = conn
+			request.Connection.Path.Index = 0
+			request.Connection.Id = m.id
+		}
+	}
+
+	if request.GetConnection().State == networkservice.State_DOWN && !m.isAlive(ctx, request.GetConnection()) {
+		// We cannot Close this because the connection was not established through this chain.
+		// We can only reselect an endpoint
+		m.logger(ctx).WithField(LogFieldNetworkServiceEndpoint, request.GetConnection().GetNetworkServiceEndpointName()).
+			Infof("NetworkServiceEndpoint %v is unavailable. Reconnection...", request.GetConnection().GetNetworkServiceEndpointName())
+		request.GetConnection().Mechanism = nil
+		request.GetConnection().NetworkServiceEndpointName = ""
+		request.GetConnection().State = networkservice.State_RESELECT_REQUESTED
+	}
+	return request
+}
+
+func (m *Manager) isAlive(ctx context.Context, conn *networkservice.Connection) bool {
+	if m.livenessCheck == nil {
+		return false
+	}
+	lCheckCtx, lCheckCtxCancel := context.WithTimeout(ctx, m.livenessCheckTimeout)
+	defer lCheckCtxCancel()
+	return m.livenessCheck(lCheckCtx, conn)
+}
+
+func (m *Manager) mergeLabels(urlLabels map[string]string) map[string]string {
+	if len(m.labels) == 0 {
+		return urlLabels
+	}
+	labels := make(map[string]string, len(m.labels)+len(urlLabels))
+	for k, v := range m.labels {
+		labels[k] = v
+	}
+	for k, v := range urlLabels {
+		labels[k] = v
+	}
+	return labels
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Store - persists the established connections, so they can be requested again after the client restart
+type Store interface {
+	// Load - returns the stored connection with the id or nil
+	Load(id string) *networkservice.Connection
+	// Save - stores the connection with the id
+	Save(id string, conn *networkservice.Connection) error
+	// Delete - deletes the connection with the id
+	Delete(id string) error
+}
+
+func (m *Manager) storeConnection(ctx context.Context, conn *networkservice.Connection) {
+	if m.store == nil {
+		return
+	}
+	if err := m.store.Save(m.id, conn); err != nil {
+		m.logger(ctx).Warnf("failed to store connection %s: %v", m.id, err.Error())
+	}
+}
+
+func (m *Manager) deleteStoredConnection(ctx context.Context) {
+	if m.store == nil {
+		return
+	}
+	if err := m.store.Delete(m.id); err != nil {
+		m.logger(ctx).Warnf("failed to delete stored connection %s: %v", m.id, err.Error())
+	}
+}
+
+// storedConnection - returns the stored connection if it is a connection to the same Network Service with the same
+// mechanism
+func (m *Manager) storedConnection(networkService, mechanism string) *networkservice.Connection {
+	if m.store == nil {
+		return nil
+	}
+	conn := m.store.Load(m.id)
+	if conn.GetNetworkService() != networkService || conn.GetMechanism().GetType() != mechanism {
+		return nil
+	}
+	conn = conn.Clone()
+	conn.Id = m.id
+	if conn.GetPath() != nil {
+		conn.GetPath().Index = 0
+	}
+	return conn
+}
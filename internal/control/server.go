@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package control provides a local control API for the applications sharing the pod with nsc. It implements
+// networkservice.NetworkServiceServer to request and close connections, and networkservice.MonitorConnectionServer
+// to list, get and watch them.
+package control
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/config"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+)
+
+// Server - local control API server
+type Server struct {
+	config *config.Config
+	pool   *connection.Pool
+	broker *connection.EventBroker
+}
+
+// NewServer - creates a Server requesting the Network Services with the pool and watching the events of the broker.
+// c provides the defaults for the requested Network Services.
+func NewServer(c *config.Config, pool *connection.Pool, broker *connection.EventBroker) *Server {
+	return &Server{
+		config: c,
+		pool:   pool,
+		broker: broker,
+	}
+}
+
+// Request - requests a Network Service by the NSURL set as request.Connection.NetworkService, for example
+// "kernel://my-service/nsm-2?app=foo". If request.Connection.Id is set to an existing connection, it is returned.
+func (s *Server) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if id := request.GetConnection().GetId(); id != "" {
+		if m := s.pool.Manager(id); m != nil && m.Connection() != nil {
+			return m.Connection(), nil
+		}
+		return nil, status.Errorf(codes.NotFound, "connection %s is not found", id)
+	}
+
+	service, err := s.config.ParseNetworkService(request.GetConnection().GetNetworkService())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	m, err := s.pool.Add(ctx, service)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return m.Connection(), nil
+}
+
+// Close - closes the connection with conn.Id, the connection is removed even if NSMgr fails to close it
+func (s *Server) Close(ctx context.Context, conn *networkservice.Connection) (*emptypb.Empty, error) {
+	if err := s.pool.Remove(ctx, conn.GetId()); err != nil {
+		if errors.Is(err, connection.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return new(emptypb.Empty), nil
+}
+
+// MonitorConnections - sends the established connections matching the selector as the initial event, then the
+// events of the matching connections. The connections are matched by the IDs of the selector path segments, the
+// empty selector matches all connections.
+func (s *Server) MonitorConnections(selector *networkservice.MonitorScopeSelector, srv networkservice.MonitorConnection_MonitorConnectionsServer) error {
+	events := s.broker.Subscribe(srv.Context())
+
+	initial := make(map[string]*networkservice.Connection)
+	for _, m := range s.pool.Managers() {
+		if conn := m.Connection(); conn != nil && matches(selector, m.ID()) {
+			initial[m.ID()] = conn
+		}
+	}
+	if err := srv.Send(&networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
+		Connections: initial,
+	}); err != nil {
+		return err
+	}
+
+	for event := range events {
+		if event.Connection == nil || !matches(selector, event.ID) {
+			continue
+		}
+		eventType := networkservice.ConnectionEventType_UPDATE
+		if event.Type == connection.EventClosed {
+			eventType = networkservice.ConnectionEventType_DELETE
+		}
+		if err := srv.Send(&networkservice.ConnectionEvent{
+			Type:        eventType,
+			Connections: map[string]*networkservice.Connection{event.ID: event.Connection},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matches(selector *networkservice.MonitorScopeSelector, id string) bool {
+	if len(selector.GetPathSegments()) == 0 {
+		return true
+	}
+	for _, segment := range selector.GetPathSegments() {
+		if segment.GetId() == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/config"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/control"
+)
+
+type closeFailingClient struct {
+	networkservice.NetworkServiceClient
+}
+
+func (c *closeFailingClient) Close(context.Context, *networkservice.Connection, ...grpc.CallOption) (*emptypb.Empty, error) {
+	return nil, errors.New("NSMgr is unreachable")
+}
+
+func TestServer_RequestClose(t *testing.T) {
+	c := &config.Config{
+		Name:           "nsc",
+		Mechanism:      "kernel",
+		RequestTimeout: time.Second,
+	}
+	broker := connection.NewEventBroker()
+	pool := connection.NewPool(c.Name, func(id string, service *config.NetworkService) *connection.Manager {
+		return connection.NewManager(null.NewClient(), id, service.URL, connection.WithEventBroker(broker))
+	}, 0)
+	s := control.NewServer(c, pool, broker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := broker.Subscribe(ctx)
+
+	conn, err := s.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{NetworkService: "my-service/nsm-2?app=foo"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nsc-0", conn.GetId())
+	require.Equal(t, "foo", conn.GetLabels()["app"])
+	require.Equal(t, connection.EventRequested, (<-events).Type)
+	require.Equal(t, connection.EventEstablished, (<-events).Type)
+
+	got, err := s.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: conn.GetId()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, conn, got)
+
+	_, err = s.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{NetworkService: "tap://my-service"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, connection.EventClosed, (<-events).Type)
+	require.Empty(t, pool.Managers())
+
+	_, err = s.Close(ctx, conn)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_CloseFailed(t *testing.T) {
+	c := &config.Config{
+		Name:           "nsc",
+		Mechanism:      "kernel",
+		RequestTimeout: time.Second,
+	}
+	broker := connection.NewEventBroker()
+	pool := connection.NewPool(c.Name, func(id string, service *config.NetworkService) *connection.Manager {
+		return connection.NewManager(&closeFailingClient{null.NewClient()}, id, service.URL, connection.WithEventBroker(broker))
+	}, 0)
+	s := control.NewServer(c, pool, broker)
+
+	conn, err := s.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{NetworkService: "my-service"},
+	})
+	require.NoError(t, err)
+
+	// The failed close is not reported as a missing connection
+	_, err = s.Close(context.Background(), conn)
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Empty(t, pool.Managers())
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dnsdiag provides the local DNS server diagnostics: the query log and the dump of the DNS configs per
+// connection
+package dnsdiag
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsurl"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/status"
+)
+
+// Connection - DNS configs of the connection
+type Connection struct {
+	ID             string              `json:"id"`
+	NetworkService string              `json:"networkService,omitempty"`
+	DNSConfigs     []*status.DNSConfig `json:"dnsConfigs,omitempty"`
+}
+
+// Diagnostics - maps the DNS configs of dnsConfigsMap to the connections and their Network Services
+type Diagnostics struct {
+	dnsConfigsMap   *genericsync.Map[string, []*networkservice.DNSConfig]
+	mu              sync.Mutex
+	networkServices map[string]string
+}
+
+// New - creates Diagnostics of dnsConfigsMap
+func New(dnsConfigsMap *genericsync.Map[string, []*networkservice.DNSConfig]) *Diagnostics {
+	return &Diagnostics{
+		dnsConfigsMap:   dnsConfigsMap,
+		networkServices: make(map[string]string),
+	}
+}
+
+// Watch - tracks the Network Services of the connections until ctx is done
+func (d *Diagnostics) Watch(ctx context.Context, broker *connection.EventBroker) {
+	events := broker.Subscribe(ctx)
+
+	go func() {
+		for event := range events {
+			d.mu.Lock()
+			if event.Type == connection.EventClosed {
+				delete(d.networkServices, event.ID)
+			} else if u, err := url.Parse(event.NetworkService); err == nil {
+				d.networkServices[event.ID] = (*nsurl.NSURL)(u).NetworkService()
+			} else {
+				log.FromContext(ctx).Warnf("invalid Network Service URL %s: %v", event.NetworkService, err.Error())
+			}
+			d.mu.Unlock()
+		}
+	}()
+}
+
+// Connections - returns the DNS configs of the connections sorted by the connection ID
+func (d *Diagnostics) Connections() []*Connection {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var connections []*Connection
+	d.dnsConfigsMap.Range(func(id string, dnsConfigs []*networkservice.DNSConfig) bool {
+		c := &Connection{
+			ID:             id,
+			NetworkService: d.networkServices[id],
+		}
+		for _, dnsConfig := range dnsConfigs {
+			c.DNSConfigs = append(c.DNSConfigs, &status.DNSConfig{
+				DNSServerIPs:  dnsConfig.GetDnsServerIps(),
+				SearchDomains: dnsConfig.GetSearchDomains(),
+			})
+		}
+		connections = append(connections, c)
+		return true
+	})
+	sort.Slice(connections, func(i, j int) bool {
+		return connections[i].ID < connections[j].ID
+	})
+	return connections
+}
+
+// ServeHTTP - writes the DNS configs of the connections as JSON
+func (d *Diagnostics) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(rw)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(d.Connections())
+}
+
+// lookup - returns the connection ID and the Network Service name of the upstream DNS server address, empty if the
+// server is not found
+func (d *Diagnostics) lookup(address string) (id, networkService string) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	d.dnsConfigsMap.Range(func(key string, dnsConfigs []*networkservice.DNSConfig) bool {
+		for _, dnsConfig := range dnsConfigs {
+			if slices.Contains(dnsConfig.GetDnsServerIps(), host) {
+				id = key
+				return false
+			}
+		}
+		return true
+	})
+	if id == "" {
+		return "", ""
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return id, d.networkServices[id]
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsdiag_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/dnsdiag"
+)
+
+func TestDiagnostics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dnsConfigsMap := new(genericsync.Map[string, []*networkservice.DNSConfig])
+	dnsConfigsMap.Store("conn-b", []*networkservice.DNSConfig{{DnsServerIps: []string{"10.0.0.2"}}})
+	dnsConfigsMap.Store("conn-a", []*networkservice.DNSConfig{{DnsServerIps: []string{"10.0.0.1"}, SearchDomains: []string{"my.domain"}}})
+
+	broker := connection.NewEventBroker()
+	diagnostics := dnsdiag.New(dnsConfigsMap)
+	diagnostics.Watch(ctx, broker)
+
+	broker.Publish(ctx, connection.Event{Type: connection.EventEstablished, ID: "conn-a", NetworkService: "kernel://my-service/nsm-1"})
+
+	var connections []*dnsdiag.Connection
+	require.Eventually(t, func() bool {
+		rw := httptest.NewRecorder()
+		diagnostics.ServeHTTP(rw, httptest.NewRequest("GET", "/debug/dns", nil))
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &connections))
+		return len(connections) == 2 && connections[0].NetworkService != ""
+	}, time.Second, 10*time.Millisecond)
+
+	require.Equal(t, "conn-a", connections[0].ID)
+	require.Equal(t, "my-service", connections[0].NetworkService)
+	require.Equal(t, []string{"10.0.0.1"}, connections[0].DNSConfigs[0].DNSServerIPs)
+	require.Equal(t, []string{"my.domain"}, connections[0].DNSConfigs[0].SearchDomains)
+	require.Equal(t, "conn-b", connections[1].ID)
+	require.Empty(t, connections[1].NetworkService)
+
+	broker.Publish(ctx, connection.Event{Type: connection.EventClosed, ID: "conn-a"})
+	require.Eventually(t, func() bool {
+		return diagnostics.Connections()[0].NetworkService == ""
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsdiag
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/localdns"
+)
+
+type queryLogHandler struct {
+	diagnostics *Diagnostics
+	sampleRate  float64
+	rateLimit   int
+
+	mu     sync.Mutex
+	window time.Time
+	logged int
+}
+
+// NewQueryLogHandler - returns a DNS handler logging the sampleRate part of the queries with the upstream server and
+// the Network Service answered them, at most rateLimit queries per second are logged, 0 means no limit. It should be
+// the first in the chain.
+func NewQueryLogHandler(d *Diagnostics, sampleRate float64, rateLimit int) dnsutils.Handler {
+	return &queryLogHandler{
+		diagnostics: d,
+		sampleRate:  sampleRate,
+		rateLimit:   rateLimit,
+	}
+}
+
+func (h *queryLogHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	start := time.Now()
+	if len(m.Question) == 0 || !h.sample(start) {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	info := new(localdns.QueryInfo)
+	writer := &responseWriter{ResponseWriter: rw, rcode: -1}
+	next.Handler(ctx).ServeDNS(localdns.WithQueryInfo(ctx, info), writer, m)
+
+	upstream := info.Upstream()
+	id, networkService := h.diagnostics.lookup(upstream)
+	log.FromContext(ctx).WithField("queryLogHandler", "ServeDNS").
+		WithField("name", m.Question[0].Name).
+		WithField("type", dns.TypeToString[m.Question[0].Qtype]).
+		WithField("upstream", upstream).
+		WithField(connection.LogFieldConnectionID, id).
+		WithField(connection.LogFieldNetworkService, networkService).
+		WithField("rcode", dns.RcodeToString[writer.rcode]).
+		WithField("latency", time.Since(start).String()).
+		Infof("DNS query served")
+}
+
+// sample - decides if the query is logged
+func (h *queryLogHandler) sample(now time.Time) bool {
+	// #nosec G404 - sampling doesn't need a cryptographically secure random
+	if h.sampleRate < 1 && rand.Float64() >= h.sampleRate {
+		return false
+	}
+	if h.rateLimit == 0 {
+		return true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if now.Sub(h.window) >= time.Second {
+		h.window = now
+		h.logged = 0
+	}
+	if h.logged >= h.rateLimit {
+		return false
+	}
+	h.logged++
+	return true
+}
+
+type responseWriter struct {
+	dns.ResponseWriter
+	rcode int
+}
+
+func (w *responseWriter) WriteMsg(m *dns.Msg) error {
+	w.rcode = m.Rcode
+	return w.ResponseWriter.WriteMsg(m)
+}
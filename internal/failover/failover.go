@@ -0,0 +1,236 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package failover provides gRPC dialing of the first reachable NSMgr from an ordered list, the healthy NSMgrs first
+package failover
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	scheme     = "nsmgr"
+	unixPrefix = "unix://"
+
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = time.Second
+)
+
+// Target - returns the URL to dial and the dial options failing over urls in order. A single URL is returned as is.
+//
+// The URLs are resolved to the addresses of one gRPC connection. gRPC tries them in order until one is reachable and
+// uses it until it breaks, then the addresses are tried in order again. The NSMgrs are checked with the gRPC health
+// service every health check interval and when the connection breaks, the healthy ones are moved to the front of the
+// addresses keeping their order. An NSMgr not serving the health service is healthy while it is reachable.
+func Target(urls []url.URL, opts ...Option) (*url.URL, []grpc.DialOption) {
+	if len(urls) == 1 {
+		return &urls[0], nil
+	}
+
+	b := &builder{
+		healthCheckInterval: defaultHealthCheckInterval,
+		healthCheckTimeout:  defaultHealthCheckTimeout,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	for i := range urls {
+		addr := urls[i].Host
+		if urls[i].Scheme == "unix" {
+			addr = unixPrefix + urls[i].Path
+		}
+		b.addresses = append(b.addresses, resolver.Address{Addr: addr})
+	}
+
+	target := &url.URL{Scheme: scheme, Path: "/" + scheme}
+	return target, []grpc.DialOption{
+		grpc.WithResolvers(b),
+		grpc.WithContextDialer(dial),
+	}
+}
+
+func dial(ctx context.Context, addr string) (net.Conn, error) {
+	network := "tcp"
+	if path, ok := strings.CutPrefix(addr, unixPrefix); ok {
+		network, addr = "unix", path
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, addr)
+}
+
+type builder struct {
+	addresses           []resolver.Address
+	healthCheckInterval time.Duration
+	healthCheckTimeout  time.Duration
+}
+
+// Build - checks the NSMgrs health with the transport credentials of cc before resolving the addresses, so the
+// healthy NSMgrs are tried first from the start
+func (b *builder) Build(_ resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	creds := opts.DialCreds
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &healthResolver{
+		cc:         cc,
+		interval:   b.healthCheckInterval,
+		timeout:    b.healthCheckTimeout,
+		resolveNow: make(chan struct{}, 1),
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	for _, address := range b.addresses {
+		conn, err := grpc.NewClient("passthrough:///"+address.Addr, grpc.WithTransportCredentials(creds), grpc.WithContextDialer(dial))
+		if err != nil {
+			cancel()
+			r.closeProbes()
+			return nil, errors.Wrapf(err, "failed to create health client of %s", address.Addr)
+		}
+		r.probes = append(r.probes, &probe{
+			address: address,
+			conn:    conn,
+			client:  grpc_health_v1.NewHealthClient(conn),
+		})
+	}
+
+	r.update(ctx)
+	go r.watch(ctx)
+	return r, nil
+}
+
+func (b *builder) Scheme() string {
+	return scheme
+}
+
+// probe - health client of an NSMgr address
+type probe struct {
+	address resolver.Address
+	conn    *grpc.ClientConn
+	client  grpc_health_v1.HealthClient
+}
+
+func (p *probe) healthy(ctx context.Context, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := p.client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return status.Code(err) == codes.Unimplemented
+	}
+	return resp.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+// healthResolver - resolves the addresses ordered by the NSMgrs health
+type healthResolver struct {
+	cc         resolver.ClientConn
+	probes     []*probe
+	interval   time.Duration
+	timeout    time.Duration
+	order      []int
+	resolveNow chan struct{}
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+func (r *healthResolver) watch(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.resolveNow:
+		}
+		r.update(ctx)
+	}
+}
+
+// update - checks the NSMgrs health and updates the addresses if their order is changed
+func (r *healthResolver) update(ctx context.Context) {
+	healthy := make([]bool, len(r.probes))
+	var wg sync.WaitGroup
+	for i, p := range r.probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			healthy[i] = p.healthy(ctx, r.timeout)
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	order := make([]int, 0, len(r.probes))
+	for _, wantHealthy := range []bool{true, false} {
+		for i := range r.probes {
+			if healthy[i] == wantHealthy {
+				order = append(order, i)
+			}
+		}
+	}
+	if slices.Equal(order, r.order) {
+		return
+	}
+	r.order = order
+
+	addresses := make([]resolver.Address, 0, len(order))
+	for _, i := range order {
+		addresses = append(addresses, r.probes[i].address)
+	}
+	if err := r.cc.UpdateState(resolver.State{Addresses: addresses}); err != nil {
+		r.cc.ReportError(err)
+	}
+}
+
+// ResolveNow - checks the NSMgrs health, it is called by gRPC when the connection breaks
+func (r *healthResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+func (r *healthResolver) Close() {
+	r.cancel()
+	<-r.done
+	r.closeProbes()
+}
+
+func (r *healthResolver) closeProbes() {
+	for _, p := range r.probes {
+		_ = p.conn.Close()
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/failover"
+)
+
+// serve - starts fake NSMgr reporting the health of the service with its name only
+func serve(t *testing.T, network, address, name string) (*grpc.Server, *health.Server, url.URL) {
+	l, err := net.Listen(network, address)
+	require.NoError(t, err)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_SERVING)
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go func() {
+		_ = server.Serve(l)
+	}()
+	t.Cleanup(server.Stop)
+
+	if network == "unix" {
+		return server, healthServer, url.URL{Scheme: "unix", Path: address}
+	}
+	return server, healthServer, url.URL{Scheme: "tcp", Host: l.Addr().String()}
+}
+
+func dial(t *testing.T, urls ...url.URL) grpc_health_v1.HealthClient {
+	target, options := failover.Target(urls, failover.WithHealthCheckInterval(10*time.Millisecond))
+	options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	cc, err := grpc.NewClient(grpcutils.URLToTarget(target), options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+	return grpc_health_v1.NewHealthClient(cc)
+}
+
+// servedBy - returns the status code of the health check of the NSMgr name
+func servedBy(client grpc_health_v1.HealthClient, name string) codes.Code {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: name}, grpc.WaitForReady(true))
+	return status.Code(err)
+}
+
+func TestTarget_Single(t *testing.T) {
+	u := url.URL{Scheme: "tcp", Host: "127.0.0.1:5001"}
+	target, options := failover.Target([]url.URL{u})
+	require.Equal(t, &u, target)
+	require.Empty(t, options)
+}
+
+func TestTarget_Order(t *testing.T) {
+	_, _, nsmgr1 := serve(t, "unix", filepath.Join(t.TempDir(), "nsm.io.sock"), "nsmgr-1")
+	_, _, nsmgr2 := serve(t, "tcp", "127.0.0.1:0", "nsmgr-2")
+
+	client := dial(t, nsmgr1, nsmgr2)
+	require.Equal(t, codes.OK, servedBy(client, "nsmgr-1"))
+	require.Equal(t, codes.NotFound, servedBy(client, "nsmgr-2"))
+}
+
+func TestTarget_Unreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := url.URL{Scheme: "tcp", Host: l.Addr().String()}
+	require.NoError(t, l.Close())
+	_, _, nsmgr2 := serve(t, "tcp", "127.0.0.1:0", "nsmgr-2")
+
+	client := dial(t, unreachable, nsmgr2)
+	require.Equal(t, codes.OK, servedBy(client, "nsmgr-2"))
+}
+
+func TestTarget_Failover(t *testing.T) {
+	server1, _, nsmgr1 := serve(t, "unix", filepath.Join(t.TempDir(), "nsm.io.sock"), "nsmgr-1")
+	_, _, nsmgr2 := serve(t, "tcp", "127.0.0.1:0", "nsmgr-2")
+
+	client := dial(t, nsmgr1, nsmgr2)
+	require.Equal(t, codes.OK, servedBy(client, "nsmgr-1"))
+
+	// The next NSMgr of the list is used once the first one is gone
+	server1.Stop()
+	require.Eventually(t, func() bool {
+		return servedBy(client, "nsmgr-2") == codes.OK
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, codes.NotFound, servedBy(client, "nsmgr-1"))
+}
+
+func TestTarget_Healthy(t *testing.T) {
+	_, health1, nsmgr1 := serve(t, "unix", filepath.Join(t.TempDir(), "nsm.io.sock"), "nsmgr-1")
+	health1.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	_, _, nsmgr2 := serve(t, "tcp", "127.0.0.1:0", "nsmgr-2")
+
+	client := dial(t, nsmgr1, nsmgr2)
+	require.Equal(t, codes.OK, servedBy(client, "nsmgr-2"))
+}
+
+func TestTarget_HealthyFailover(t *testing.T) {
+	server1, _, nsmgr1 := serve(t, "unix", filepath.Join(t.TempDir(), "nsm.io.sock"), "nsmgr-1")
+	_, health2, nsmgr2 := serve(t, "tcp", "127.0.0.1:0", "nsmgr-2")
+	_, _, nsmgr3 := serve(t, "tcp", "127.0.0.1:0", "nsmgr-3")
+
+	client := dial(t, nsmgr1, nsmgr2, nsmgr3)
+	require.Equal(t, codes.OK, servedBy(client, "nsmgr-1"))
+
+	// The connection is kept while nsmgr-2 becomes unhealthy, the healthy nsmgr-3 is used once nsmgr-1 is gone
+	health2.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, codes.OK, servedBy(client, "nsmgr-1"))
+
+	server1.Stop()
+	require.Eventually(t, func() bool {
+		return servedBy(client, "nsmgr-3") == codes.OK
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, codes.NotFound, servedBy(client, "nsmgr-2"))
+}
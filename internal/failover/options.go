@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failover
+
+import "time"
+
+// Option - Target option
+type Option func(b *builder)
+
+// WithHealthCheckInterval - sets an interval between the health checks of the NSMgrs
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(b *builder) {
+		b.healthCheckInterval = interval
+	}
+}
+
+// WithHealthCheckTimeout - sets a timeout of a single health check of an NSMgr
+func WithHealthCheckTimeout(timeout time.Duration) Option {
+	return func(b *builder) {
+		b.healthCheckTimeout = timeout
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fileutils provides file helpers shared by the NSC state and status files
+package fileutils
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// WriteFileAtomic - writes data to the file at path atomically, so the readers never see a partially written file
+func WriteFileAtomic(path string, data []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to write temporary file")
+	}
+	if err = tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "failed to change temporary file mode")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to close temporary file")
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), path), "failed to rename temporary file to %s", path)
+}
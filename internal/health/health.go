@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health provides liveness and readiness probes of the NSC
+package health
+
+import (
+	"net/http"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/nsurl"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+)
+
+// Handler - serves /healthz, /readyz and /livez probes
+type Handler struct {
+	*http.ServeMux
+	pool            *connection.Pool
+	cc              *grpc.ClientConn
+	networkServices map[string]struct{}
+}
+
+// NewHandler - creates a new Handler.
+//   - pool - connections which must be established to be ready
+//   - cc - connection to NSMgr which must be healthy to be live
+//   - networkServices - names of Network Services required to be ready, empty means all of them
+func NewHandler(pool *connection.Pool, cc *grpc.ClientConn, networkServices ...string) *Handler {
+	h := &Handler{
+		ServeMux:        http.NewServeMux(),
+		pool:            pool,
+		cc:              cc,
+		networkServices: make(map[string]struct{}, len(networkServices)),
+	}
+	for _, name := range networkServices {
+		h.networkServices[name] = struct{}{}
+	}
+	h.HandleFunc("/healthz", h.probe(func() bool { return true }))
+	h.HandleFunc("/livez", h.probe(h.live))
+	h.HandleFunc("/readyz", h.probe(h.ready))
+	return h
+}
+
+// ready - returns true if all required connections are established and are not reported DOWN. The empty pool and
+// the required Network Services not requested yet are not ready.
+func (h *Handler) ready() bool {
+	if !h.live() {
+		return false
+	}
+	managers := h.pool.Managers()
+	if len(managers) == 0 {
+		return false
+	}
+	requested := make(map[string]struct{}, len(h.networkServices))
+	for _, m := range managers {
+		if len(h.networkServices) > 0 {
+			name := (*nsurl.NSURL)(m.NetworkService()).NetworkService()
+			if _, ok := h.networkServices[name]; !ok {
+				continue
+			}
+			requested[name] = struct{}{}
+		}
+		if !m.Ready() {
+			return false
+		}
+	}
+	return len(requested) == len(h.networkServices)
+}
+
+// live - returns true if the gRPC connection to NSMgr is healthy
+func (h *Handler) live() bool {
+	if h.cc == nil {
+		return true
+	}
+	switch h.cc.GetState() {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return false
+	default:
+		return true
+	}
+}
+
+func (h *Handler) probe(check func() bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if !check() {
+			http.Error(w, "not ok", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/config"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/health"
+)
+
+func probe(handler http.Handler, path string) int {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	return w.Code
+}
+
+func newPool() *connection.Pool {
+	return connection.NewPool("nsc", func(id string, service *config.NetworkService) *connection.Manager {
+		if service.URL.Host == "down" {
+			return connection.NewManager(injecterror.NewClient(), id, service.URL, connection.WithMaxAttempts(1))
+		}
+		return connection.NewManager(null.NewClient(), id, service.URL)
+	}, 0)
+}
+
+func TestHandler_Ready(t *testing.T) {
+	pool := newPool()
+	defer pool.Stop(context.Background())
+
+	var services []*config.NetworkService
+	for _, rawURL := range []string{"kernel://up/nsm-1", "kernel://down/nsm-2"} {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		services = append(services, &config.NetworkService{URL: u})
+	}
+	_ = pool.Update(context.Background(), services)
+
+	all := health.NewHandler(pool, nil)
+	require.Equal(t, http.StatusOK, probe(all, "/livez"))
+	require.Equal(t, http.StatusOK, probe(all, "/healthz"))
+	require.Equal(t, http.StatusServiceUnavailable, probe(all, "/readyz"))
+
+	subset := health.NewHandler(pool, nil, "up")
+	require.Equal(t, http.StatusOK, probe(subset, "/readyz"))
+
+	// The required Network Service is not requested
+	missing := health.NewHandler(pool, nil, "up", "other")
+	require.Equal(t, http.StatusServiceUnavailable, probe(missing, "/readyz"))
+}
+
+func TestHandler_EmptyPool(t *testing.T) {
+	// Nothing is requested yet, the process is alive but not ready
+	h := health.NewHandler(newPool(), nil)
+	require.Equal(t, http.StatusOK, probe(h, "/healthz"))
+	require.Equal(t, http.StatusOK, probe(h, "/livez"))
+	require.Equal(t, http.StatusServiceUnavailable, probe(h, "/readyz"))
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hooks runs user commands on the connection lifecycle events
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/status"
+)
+
+// Hook events
+const (
+	// EventUp - the connection is established
+	EventUp = "up"
+	// EventHeal - the connection reported DOWN is UP again with the same NSE
+	EventHeal = "heal"
+	// EventReselect - the connection reported DOWN is UP again with another NSE
+	EventReselect = "reselect"
+	// EventDown - the connection is reported DOWN by NSMgr
+	EventDown = "down"
+	// EventClose - the connection is closed
+	EventClose = "close"
+)
+
+const queueSize = 1024
+
+var hookEvents = map[connection.EventType]string{
+	connection.EventEstablished: EventUp,
+	connection.EventHealed:      EventHeal,
+	connection.EventReselected:  EventReselect,
+	connection.EventDown:        EventDown,
+	connection.EventClosed:      EventClose,
+}
+
+// Payload - JSON passed to the hook on stdin
+type Payload struct {
+	Event      string             `json:"event"`
+	Connection *status.Connection `json:"connection"`
+}
+
+// Runner - runs the hooks on the connection lifecycle events one by one in the order of the events
+type Runner struct {
+	commands  []string
+	dir       string
+	timeout   time.Duration
+	onFailure func(err error)
+}
+
+// NewRunner - creates a Runner of the hook commands and the executables found in dir in lexical order, onFailure is
+// called if a hook fails
+func NewRunner(commands []string, dir string, timeout time.Duration, onFailure func(err error)) *Runner {
+	return &Runner{
+		commands:  commands,
+		dir:       dir,
+		timeout:   timeout,
+		onFailure: onFailure,
+	}
+}
+
+// Watch - starts running the hooks on the broker events until ctx is done. The hooks of the events received before
+// are still run after that, the returned channel is closed once they are completed.
+func (r *Runner) Watch(ctx context.Context, broker *connection.EventBroker) <-chan struct{} {
+	events := broker.Subscribe(ctx)
+
+	// The hooks may be slow, so the events are queued to not be dropped by the broker
+	queue := make(chan connection.Event, queueSize)
+	go func() {
+		defer close(queue)
+		for event := range events {
+			if _, ok := hookEvents[event.Type]; !ok {
+				continue
+			}
+			select {
+			case queue <- event:
+			default:
+				log.FromContext(ctx).Warnf("hooks queue is full, %s event for %s is dropped", event.Type, event.ID)
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Each hook is limited by the timeout, so the queue is drained after ctx is done
+		runCtx := context.WithoutCancel(ctx)
+		for event := range queue {
+			if err := r.run(runCtx, event); err != nil {
+				r.onFailure(err)
+			}
+		}
+	}()
+	return done
+}
+
+func (r *Runner) run(ctx context.Context, event connection.Event) error {
+	hookEvent := hookEvents[event.Type]
+	state := status.StateUp
+	switch event.Type {
+	case connection.EventDown:
+		state = status.StateDown
+	case connection.EventClosed:
+		state = status.StateClosed
+	}
+	payload := &Payload{
+		Event:      hookEvent,
+		Connection: status.NewConnection(event, state),
+	}
+	stdin, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal hook payload")
+	}
+
+	hooks, err := r.hooks()
+	if err != nil {
+		return err
+	}
+
+	env := append(os.Environ(), environment(payload)...)
+	for _, hook := range hooks {
+		if err := r.exec(ctx, hook, env, stdin); err != nil {
+			return errors.Wrapf(err, "%s hook %s failed for connection %s", hookEvent, hook, event.ID)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) exec(ctx context.Context, hook string, env []string, stdin []byte) error {
+	hookCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// #nosec G204 - hooks are configured by the cmd-nsc owner
+	cmd := exec.CommandContext(hookCtx, hook)
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(stdin)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "output: %s", strings.TrimSpace(string(output)))
+	}
+	log.FromContext(ctx).Debugf("hook %s output: %s", hook, output)
+	return nil
+}
+
+// hooks - returns the hook commands followed by the executables found in the hooks directory
+func (r *Runner) hooks() ([]string, error) {
+	hooks := append([]string(nil), r.commands...)
+	if r.dir == "" {
+		return hooks, nil
+	}
+
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read hooks directory %s", r.dir)
+	}
+	var found []string
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || info.IsDir() || info.Mode()&0o111 == 0 {
+			continue
+		}
+		found = append(found, filepath.Join(r.dir, entry.Name()))
+	}
+	sort.Strings(found)
+	return append(hooks, found...), nil
+}
+
+// environment - returns the connection details passed to the hook as environment variables
+func environment(payload *Payload) []string {
+	c := payload.Connection
+	return []string{
+		"NSC_HOOK_EVENT=" + payload.Event,
+		"NSC_CONNECTION_ID=" + c.ID,
+		"NSC_NETWORK_SERVICE_URL=" + c.URL,
+		"NSC_NETWORK_SERVICE=" + c.NetworkService,
+		"NSC_NETWORK_SERVICE_ENDPOINT=" + c.NetworkServiceEndpointName,
+		"NSC_MECHANISM=" + c.Mechanism,
+		"NSC_INTERFACE_NAME=" + c.InterfaceName,
+		"NSC_SRC_IPS=" + strings.Join(c.SrcIPs, ","),
+		"NSC_DST_IPS=" + strings.Join(c.DstIPs, ","),
+		"NSC_STATE=" + c.State,
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/hooks"
+)
+
+func TestRunner_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "output")
+	hooksDir := filepath.Join(dir, "hooks.d")
+	require.NoError(t, os.Mkdir(hooksDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(hooksDir, "10-save"), []byte(`#!/bin/sh
+cat > `+output+`.$NSC_HOOK_EVENT
+`), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(hooksDir, "README"), []byte("not executable"), 0o600))
+
+	var failures []error
+	broker := connection.NewEventBroker()
+	hooks.NewRunner(nil, hooksDir, time.Second, func(err error) { failures = append(failures, err) }).Watch(ctx, broker)
+
+	broker.Publish(ctx, connection.Event{
+		Type:           connection.EventEstablished,
+		ID:             "nsc-0",
+		NetworkService: "kernel://my-service/nsm-1",
+		Connection: &networkservice.Connection{
+			Id:                         "nsc-0",
+			NetworkService:             "my-service",
+			NetworkServiceEndpointName: "nse-1",
+		},
+	})
+
+	var data []byte
+	require.Eventually(t, func() bool {
+		var err error
+		data, err = os.ReadFile(filepath.Clean(output + ".up"))
+		return err == nil && len(data) != 0
+	}, time.Second, 10*time.Millisecond)
+
+	payload := new(hooks.Payload)
+	require.NoError(t, json.Unmarshal(data, payload))
+	require.Equal(t, hooks.EventUp, payload.Event)
+	require.Equal(t, "nse-1", payload.Connection.NetworkServiceEndpointName)
+	require.Empty(t, failures)
+}
+
+func TestRunner_Drain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	output := filepath.Join(t.TempDir(), "output")
+	hook := filepath.Join(t.TempDir(), "save")
+	require.NoError(t, os.WriteFile(hook, []byte(`#!/bin/sh
+sleep 0.1
+echo $NSC_HOOK_EVENT > `+output+`
+`), 0o700))
+
+	broker := connection.NewEventBroker()
+	done := hooks.NewRunner([]string{hook}, "", time.Second, func(err error) { require.NoError(t, err) }).Watch(ctx, broker)
+
+	// The close event published right before the shutdown is still handled
+	broker.Publish(ctx, connection.Event{
+		Type:           connection.EventClosed,
+		ID:             "nsc-0",
+		NetworkService: "kernel://my-service/nsm-1",
+		Connection:     &networkservice.Connection{Id: "nsc-0"},
+	})
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "hooks are not drained")
+	}
+	data, err := os.ReadFile(filepath.Clean(output))
+	require.NoError(t, err)
+	require.Equal(t, hooks.EventClose+"\n", string(data))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns
+
+import (
+	"context"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type blockHandler struct {
+	suffixes []string
+}
+
+// NewBlockHandler - returns a DNS handler answering NXDOMAIN to the queries of the names under the blocked suffixes
+func NewBlockHandler(suffixes ...string) dnsutils.Handler {
+	h := new(blockHandler)
+	for _, suffix := range suffixes {
+		h.suffixes = append(h.suffixes, dns.CanonicalName(strings.TrimPrefix(suffix, ".")))
+	}
+	return h
+}
+
+func (h *blockHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	name := dns.CanonicalName(m.Question[0].Name)
+	if _, ok := matchDomain(name, h.suffixes); !ok {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	resp := new(dns.Msg).SetRcode(m, dns.RcodeNameError)
+	if err := rw.WriteMsg(resp); err != nil {
+		log.FromContext(ctx).WithField("blockHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// cacheKey - question of the query, the responses to EDNS queries and to queries with DNSSEC OK bit differ by their
+// OPT and DNSSEC records
+type cacheKey struct {
+	name   string
+	qtype  uint16
+	qclass uint16
+	edns   bool
+	do     bool
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	msg     *dns.Msg
+	stored  time.Time
+	expires time.Time
+}
+
+type cacheHandler struct {
+	size   int
+	maxTTL time.Duration
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[cacheKey]*list.Element
+}
+
+// NewCacheHandler - returns a DNS handler caching up to size responses, the least recently used one is evicted when
+// the cache is full. A response is cached for the minimal TTL of its records, but not longer than maxTTL, the TTLs of
+// the cached records are decreased by the time spent in the cache.
+func NewCacheHandler(size int, maxTTL time.Duration) dnsutils.Handler {
+	return &cacheHandler{
+		size:    size,
+		maxTTL:  maxTTL,
+		lru:     list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+}
+
+func (h *cacheHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	q := m.Question[0]
+	opt := m.IsEdns0()
+	key := cacheKey{name: strings.ToLower(q.Name), qtype: q.Qtype, qclass: q.Qclass, edns: opt != nil, do: opt != nil && opt.Do()}
+
+	if resp := h.load(key); resp != nil {
+		resp.Id = m.Id
+		if err := rw.WriteMsg(resp); err != nil {
+			log.FromContext(ctx).WithField("cacheHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+		}
+		return
+	}
+
+	next.Handler(ctx).ServeDNS(ctx, &cacheResponseWriter{ResponseWriter: rw, handler: h, key: key}, m)
+}
+
+// load - returns a copy of the cached response with TTLs decreased by the time spent in the cache
+func (h *cacheHandler) load(key cacheKey) *dns.Msg {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	e, ok := h.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := e.Value.(*cacheEntry)
+	if !time.Now().Before(entry.expires) {
+		h.lru.Remove(e)
+		delete(h.entries, key)
+		return nil
+	}
+	h.lru.MoveToFront(e)
+
+	resp := entry.msg.Copy()
+	elapsed := uint32(time.Since(entry.stored).Seconds())
+	forEachTTL(resp, func(ttl uint32) uint32 {
+		if ttl < elapsed {
+			return 0
+		}
+		return ttl - elapsed
+	})
+	return resp
+}
+
+func (h *cacheHandler) store(key cacheKey, msg *dns.Msg) {
+	ttl, ok := cacheTTL(msg)
+	if !ok {
+		return
+	}
+	if ttl > h.maxTTL {
+		ttl = h.maxTTL
+	}
+	now := time.Now()
+	entry := &cacheEntry{key: key, msg: msg.Copy(), stored: now, expires: now.Add(ttl)}
+	maxTTL := uint32(h.maxTTL.Seconds())
+	forEachTTL(entry.msg, func(ttl uint32) uint32 {
+		if ttl > maxTTL {
+			return maxTTL
+		}
+		return ttl
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if e, ok := h.entries[key]; ok {
+		e.Value = entry
+		h.lru.MoveToFront(e)
+		return
+	}
+	h.entries[key] = h.lru.PushFront(entry)
+	for h.lru.Len() > h.size {
+		oldest := h.lru.Back()
+		h.lru.Remove(oldest)
+		delete(h.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// cacheTTL - returns the minimal TTL of the records, the truncated responses, the failures and the responses without
+// records are not cached
+func cacheTTL(msg *dns.Msg) (time.Duration, bool) {
+	if msg.Truncated || (msg.Rcode != dns.RcodeSuccess && msg.Rcode != dns.RcodeNameError) {
+		return 0, false
+	}
+	var minTTL uint32
+	found := false
+	for _, rrs := range [][]dns.RR{msg.Answer, msg.Ns} {
+		for _, rr := range rrs {
+			if ttl := rr.Header().Ttl; !found || ttl < minTTL {
+				minTTL, found = ttl, true
+			}
+		}
+	}
+	if !found || minTTL == 0 {
+		return 0, false
+	}
+	return time.Duration(minTTL) * time.Second, true
+}
+
+// forEachTTL - replaces TTL of every record except OPT with the result of f
+func forEachTTL(msg *dns.Msg, f func(ttl uint32) uint32) {
+	for _, rrs := range [][]dns.RR{msg.Answer, msg.Ns, msg.Extra} {
+		for _, rr := range rrs {
+			if rr.Header().Rrtype != dns.TypeOPT {
+				rr.Header().Ttl = f(rr.Header().Ttl)
+			}
+		}
+	}
+}
+
+type cacheResponseWriter struct {
+	dns.ResponseWriter
+	handler *cacheHandler
+	key     cacheKey
+}
+
+func (w *cacheResponseWriter) WriteMsg(m *dns.Msg) error {
+	w.handler.store(w.key, m)
+	return w.ResponseWriter.WriteMsg(m)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type fallbackHandler struct {
+	servers []url.URL
+}
+
+// NewFallbackHandler - returns a DNS handler sending the query to the servers only if the servers of the connections
+// haven't answered it successfully, it should be right before the handler querying the upstream servers. The server is
+// ip[:port].
+func NewFallbackHandler(servers ...string) (dnsutils.Handler, error) {
+	h := new(fallbackHandler)
+	for _, server := range servers {
+		u, err := ParseServer(server)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fallback DNS server")
+		}
+		h.servers = append(h.servers, *u)
+	}
+	return h, nil
+}
+
+func (h *fallbackHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if len(h.servers) == 0 {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	primary := &recordResponseWriter{ResponseWriter: rw}
+	next.Handler(ctx).ServeDNS(ctx, primary, m)
+	resp := primary.msg
+	if resp == nil || resp.Rcode != dns.RcodeSuccess {
+		fallback := &recordResponseWriter{ResponseWriter: rw}
+		next.Handler(ctx).ServeDNS(clienturlctx.WithClientURLs(ctx, h.servers), fallback, m)
+		// The negative response of the connection servers wins over the failure of the fallback servers
+		if resp == nil || fallback.msg != nil && (fallback.msg.Rcode == dns.RcodeSuccess || resp.Rcode == dns.RcodeServerFailure) {
+			resp = fallback.msg
+		}
+	}
+	if resp == nil {
+		return
+	}
+
+	if err := rw.WriteMsg(resp); err != nil {
+		log.FromContext(ctx).WithField("fallbackHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+	}
+}
+
+// recordResponseWriter - records the response instead of writing it
+type recordResponseWriter struct {
+	dns.ResponseWriter
+	msg *dns.Msg
+}
+
+func (w *recordResponseWriter) WriteMsg(m *dns.Msg) error {
+	w.msg = m
+	return nil
+}
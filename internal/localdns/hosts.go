@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// hostsTTL - TTL of the static records in seconds
+const hostsTTL = 60
+
+type hostsHandler struct {
+	// records - IP addresses by the canonical name
+	records map[string][]net.IP
+}
+
+// NewHostsHandler - returns a DNS handler answering A and AAAA queries of the names from the hosts file at path, the
+// other queries are passed to the next handler. The file has /etc/hosts format: ip name [aliases...]
+func NewHostsHandler(path string) (dnsutils.Handler, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read hosts file %s", path)
+	}
+	records, err := parseHosts(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid hosts file %s", path)
+	}
+	return &hostsHandler{records: records}, nil
+}
+
+func parseHosts(data []byte) (map[string][]net.IP, error) {
+	records := make(map[string][]net.IP)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		ip := net.ParseIP(fields[0])
+		if ip == nil || len(fields) < 2 {
+			return nil, errors.Errorf("line %d should be ip name [aliases...], got %q", line, scanner.Text())
+		}
+		for _, name := range fields[1:] {
+			name = dns.CanonicalName(name)
+			records[name] = append(records[name], ip)
+		}
+	}
+	return records, errors.WithStack(scanner.Err())
+}
+
+func (h *hostsHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	q := m.Question[0]
+	ips, ok := h.records[dns.CanonicalName(q.Name)]
+	if !ok || (q.Qtype != dns.TypeA && q.Qtype != dns.TypeAAAA) {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	// The static name is answered with its records of the type only, so it is never resolved by the upstream
+	resp := new(dns.Msg).SetReply(m)
+	resp.Authoritative = true
+	header := dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: dns.ClassINET, Ttl: hostsTTL}
+	for _, ip := range ips {
+		switch ip4 := ip.To4(); {
+		case q.Qtype == dns.TypeA && ip4 != nil:
+			resp.Answer = append(resp.Answer, &dns.A{Hdr: header, A: ip4})
+		case q.Qtype == dns.TypeAAAA && ip4 == nil:
+			resp.Answer = append(resp.Answer, &dns.AAAA{Hdr: header, AAAA: ip})
+		}
+	}
+	if err := rw.WriteMsg(resp); err != nil {
+		log.FromContext(ctx).WithField("hostsHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+	}
+}
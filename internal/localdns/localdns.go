@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package localdns - DNS handlers of the configurable local DNS server chain
+package localdns
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+)
+
+const defaultPort = "53"
+
+// ParseServer - returns the upstream DNS server URL of ip or ip:port address, the port is 53 by default
+func ParseServer(address string) (*url.URL, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		host, port = strings.Trim(address, "[]"), defaultPort
+	}
+	if net.ParseIP(host) == nil {
+		return nil, errors.Errorf("DNS server %q should be ip or ip:port", address)
+	}
+	return &url.URL{Scheme: "udp", Host: net.JoinHostPort(host, port)}, nil
+}
+
+// matchDomain - returns the longest of the domains name belongs to
+func matchDomain(name string, domains []string) (string, bool) {
+	var matched string
+	found := false
+	for _, domain := range domains {
+		if dns.IsSubDomain(domain, name) && (!found || len(domain) > len(matched)) {
+			matched, found = domain, true
+		}
+	}
+	return matched, found
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	dnschain "github.com/networkservicemesh/sdk/pkg/tools/dnsutils/chain"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/localdns"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	resp *dns.Msg
+}
+
+func (w *responseWriter) WriteMsg(m *dns.Msg) error {
+	w.resp = m
+	return nil
+}
+
+func query(ctx context.Context, t *testing.T, handler dnsutils.Handler, name string, qtype uint16) *dns.Msg {
+	rw := new(responseWriter)
+	handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion(name, qtype))
+	require.NotNil(t, rw.resp, name)
+	return rw.resp
+}
+
+type upstreamHandler struct {
+	queries int
+}
+
+func (h *upstreamHandler) ServeDNS(_ context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	h.queries++
+	resp := new(dns.Msg).SetReply(m)
+	resp.Answer = append(resp.Answer, &dns.A{
+		Hdr: dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 300},
+		A:   net.ParseIP("10.0.0.1"),
+	})
+	_ = rw.WriteMsg(resp)
+}
+
+func TestChain(t *testing.T) {
+	hostsPath := filepath.Join(t.TempDir(), "hosts")
+	require.NoError(t, os.WriteFile(hostsPath, []byte(`# static records
+192.168.0.1 db.example.org db
+`), 0o600))
+	hosts, err := localdns.NewHostsHandler(hostsPath)
+	require.NoError(t, err)
+
+	upstream := new(upstreamHandler)
+	handler := dnschain.NewDNSHandler(
+		localdns.NewBlockHandler(".ads.example.org"),
+		hosts,
+		localdns.NewCacheHandler(1, time.Minute),
+		upstream,
+	)
+	ctx := context.Background()
+
+	require.Equal(t, dns.RcodeNameError, query(ctx, t, handler, "tracker.ads.example.org.", dns.TypeA).Rcode)
+
+	resp := query(ctx, t, handler, "DB.example.org.", dns.TypeA)
+	require.Len(t, resp.Answer, 1)
+	require.Equal(t, "192.168.0.1", resp.Answer[0].(*dns.A).A.String())
+	resp = query(ctx, t, handler, "db.example.org.", dns.TypeAAAA)
+	require.Equal(t, dns.RcodeSuccess, resp.Rcode)
+	require.Empty(t, resp.Answer)
+
+	query(ctx, t, handler, "www.example.org.", dns.TypeA)
+	resp = query(ctx, t, handler, "www.example.org.", dns.TypeA)
+	require.Equal(t, uint32(60), resp.Answer[0].Header().Ttl)
+	require.Equal(t, 1, upstream.queries)
+
+	// The cache holds a single response, so the first one is evicted
+	query(ctx, t, handler, "mail.example.org.", dns.TypeA)
+	query(ctx, t, handler, "www.example.org.", dns.TypeA)
+	require.Equal(t, 3, upstream.queries)
+}
+
+func TestCacheHandler_DNSSEC(t *testing.T) {
+	queries := 0
+	handler := dnschain.NewDNSHandler(
+		localdns.NewCacheHandler(10, time.Minute),
+		handlerFunc(func(rw dns.ResponseWriter, m *dns.Msg) {
+			queries++
+			resp := answer(m, dns.RcodeSuccess, 1)
+			if opt := m.IsEdns0(); opt != nil {
+				resp.SetEdns0(opt.UDPSize(), opt.Do())
+				if opt.Do() {
+					resp.Answer = append(resp.Answer, &dns.RRSIG{
+						Hdr:         dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 300},
+						TypeCovered: dns.TypeA,
+					})
+				}
+			}
+			_ = rw.WriteMsg(resp)
+		}),
+	)
+	ctx := context.Background()
+
+	serve := func(edns, do bool) *dns.Msg {
+		m := new(dns.Msg).SetQuestion("www.example.org.", dns.TypeA)
+		if edns {
+			m.SetEdns0(dns.DefaultMsgSize, do)
+		}
+		rw := new(responseWriter)
+		handler.ServeDNS(ctx, rw, m)
+		require.NotNil(t, rw.resp)
+		return rw.resp
+	}
+
+	// The responses to the queries with different EDNS and DNSSEC OK bit are cached separately
+	for i := 0; i < 2; i++ {
+		resp := serve(false, false)
+		require.Nil(t, resp.IsEdns0())
+		require.Len(t, resp.Answer, 1)
+
+		resp = serve(true, false)
+		require.NotNil(t, resp.IsEdns0())
+		require.Len(t, resp.Answer, 1)
+
+		resp = serve(true, true)
+		require.True(t, resp.IsEdns0().Do())
+		require.Len(t, resp.Answer, 2)
+	}
+	require.Equal(t, 3, queries)
+}
+
+// answer - returns a response with n A records or with rcode if n is 0
+func answer(m *dns.Msg, rcode, n int) *dns.Msg {
+	resp := new(dns.Msg).SetRcode(m, rcode)
+	for i := 0; i < n; i++ {
+		resp.Answer = append(resp.Answer, &dns.A{
+			Hdr: dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 300},
+			A:   net.IPv4(10, 0, byte(i/256), byte(i%256)),
+		})
+	}
+	return resp
+}
+
+// serveDNS - serves the UDP and TCP handlers on a random local port, returns its address
+func serveDNS(t *testing.T, udpHandler, tcpHandler dns.HandlerFunc) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", conn.LocalAddr().String())
+	require.NoError(t, err)
+
+	for _, server := range []*dns.Server{
+		{PacketConn: conn, Handler: udpHandler},
+		{Listener: listener, Handler: tcpHandler},
+	} {
+		go func() { _ = server.ActivateAndServe() }()
+		t.Cleanup(func() { _ = server.Shutdown() })
+	}
+	return conn.LocalAddr().String()
+}
+
+func serveRcode(t *testing.T, rcode int) string {
+	handler := func(rw dns.ResponseWriter, m *dns.Msg) {
+		n := 0
+		if rcode == dns.RcodeSuccess {
+			n = 1
+		}
+		_ = rw.WriteMsg(answer(m, rcode, n))
+	}
+	return serveDNS(t, handler, handler)
+}
+
+func TestUpstreamHandler(t *testing.T) {
+	failed := serveRcode(t, dns.RcodeServerFailure)
+	succeeded := serveRcode(t, dns.RcodeSuccess)
+
+	for _, mode := range []string{localdns.UpstreamFanout, localdns.UpstreamSequential} {
+		ctx := clienturlctx.WithClientURLs(context.Background(), []url.URL{
+			{Scheme: "udp", Host: failed},
+			{Scheme: "udp", Host: succeeded},
+		})
+		resp := query(ctx, t, localdns.NewUpstreamHandler(mode, time.Second, 1232), "www.example.org.", dns.TypeA)
+		require.Equal(t, dns.RcodeSuccess, resp.Rcode, mode)
+		require.Len(t, resp.Answer, 1, mode)
+	}
+
+	rewrite, err := localdns.NewRewriteHandler("corp.example.org=" + succeeded)
+	require.NoError(t, err)
+	handler := dnschain.NewDNSHandler(rewrite, localdns.NewUpstreamHandler(localdns.UpstreamSequential, time.Second, 1232))
+	ctx := clienturlctx.WithClientURLs(context.Background(), []url.URL{{Scheme: "udp", Host: failed}})
+
+	require.Equal(t, dns.RcodeServerFailure, query(ctx, t, handler, "www.example.org.", dns.TypeA).Rcode)
+	require.Equal(t, dns.RcodeSuccess, query(ctx, t, handler, "db.corp.example.org.", dns.TypeA).Rcode)
+}
+
+func TestFallbackHandler(t *testing.T) {
+	failed := serveRcode(t, dns.RcodeServerFailure)
+	notFound := serveRcode(t, dns.RcodeNameError)
+	succeeded := serveRcode(t, dns.RcodeSuccess)
+
+	var fallbackQueries atomic.Int32
+	fallbackHandler := func(rw dns.ResponseWriter, m *dns.Msg) {
+		fallbackQueries.Add(1)
+		_ = rw.WriteMsg(answer(m, dns.RcodeSuccess, 1))
+	}
+	fallback, err := localdns.NewFallbackHandler(serveDNS(t, fallbackHandler, fallbackHandler))
+	require.NoError(t, err)
+	handler := dnschain.NewDNSHandler(fallback, localdns.NewUpstreamHandler(localdns.UpstreamFanout, time.Second, 1232))
+
+	for _, server := range []string{failed, notFound} {
+		ctx := clienturlctx.WithClientURLs(context.Background(), []url.URL{{Scheme: "udp", Host: server}})
+		require.Equal(t, dns.RcodeSuccess, query(ctx, t, handler, "www.example.org.", dns.TypeA).Rcode)
+	}
+	require.Equal(t, int32(2), fallbackQueries.Load())
+
+	// The fallback servers aren't queried in parallel with the servers of the connections
+	ctx := clienturlctx.WithClientURLs(context.Background(), []url.URL{{Scheme: "udp", Host: succeeded}})
+	require.Equal(t, dns.RcodeSuccess, query(ctx, t, handler, "www.example.org.", dns.TypeA).Rcode)
+	require.Equal(t, int32(2), fallbackQueries.Load())
+}
+
+func TestUpstreamHandler_TruncatedRetry(t *testing.T) {
+	server := serveDNS(t,
+		func(rw dns.ResponseWriter, m *dns.Msg) {
+			resp := answer(m, dns.RcodeSuccess, 0)
+			resp.Truncated = true
+			_ = rw.WriteMsg(resp)
+		},
+		func(rw dns.ResponseWriter, m *dns.Msg) {
+			_ = rw.WriteMsg(answer(m, dns.RcodeSuccess, 100))
+		},
+	)
+
+	ctx := clienturlctx.WithClientURLs(context.Background(), []url.URL{{Scheme: "udp", Host: server}})
+	resp := query(ctx, t, localdns.NewUpstreamHandler(localdns.UpstreamFanout, time.Second, 1232), "srv.example.org.", dns.TypeA)
+	require.False(t, resp.Truncated)
+	require.Len(t, resp.Answer, 100)
+	require.Nil(t, resp.IsEdns0())
+}
+
+func TestListenAndServe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := conn.LocalAddr().String()
+	require.NoError(t, conn.Close())
+
+	localdns.ListenAndServe(ctx, dnschain.NewDNSHandler(handlerFunc(func(rw dns.ResponseWriter, m *dns.Msg) {
+		_ = rw.WriteMsg(answer(m, dns.RcodeSuccess, 100))
+	})), address)
+
+	m := new(dns.Msg).SetQuestion("srv.example.org.", dns.TypeA)
+	var resp *dns.Msg
+	require.Eventually(t, func() bool {
+		resp, _, err = new(dns.Client).Exchange(m, address)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.True(t, resp.Truncated)
+	require.Less(t, len(resp.Answer), 100)
+
+	require.Eventually(t, func() bool {
+		resp, _, err = (&dns.Client{Net: "tcp"}).Exchange(m, address)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.False(t, resp.Truncated)
+	require.Len(t, resp.Answer, 100)
+}
+
+type handlerFunc func(rw dns.ResponseWriter, m *dns.Msg)
+
+func (f handlerFunc) ServeDNS(_ context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	f(rw, m)
+}
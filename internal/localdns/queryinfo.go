@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns
+
+import (
+	"context"
+	"sync"
+)
+
+type queryInfoKey struct{}
+
+// QueryInfo - details of the query recorded by the handlers of the chain
+type QueryInfo struct {
+	mu       sync.Mutex
+	upstream string
+}
+
+// WithQueryInfo - returns ctx the handlers record the query details to info with
+func WithQueryInfo(ctx context.Context, info *QueryInfo) context.Context {
+	return context.WithValue(ctx, queryInfoKey{}, info)
+}
+
+// Upstream - returns the address of the upstream server which response is written last, empty if the query is
+// answered without the upstream servers
+func (i *QueryInfo) Upstream() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.upstream
+}
+
+func setUpstream(ctx context.Context, address string) {
+	if info, ok := ctx.Value(queryInfoKey{}).(*QueryInfo); ok {
+		info.mu.Lock()
+		info.upstream = address
+		info.mu.Unlock()
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+)
+
+type rewriteHandler struct {
+	domains []string
+	servers map[string][]url.URL
+}
+
+// NewRewriteHandler - returns a DNS handler sending the queries of the names under the rule domains to the rule
+// servers instead of the servers of the connections, the longest domain wins. The rule is domain=ip[:port], the rules
+// of the same domain are merged.
+func NewRewriteHandler(rules ...string) (dnsutils.Handler, error) {
+	h := &rewriteHandler{
+		servers: make(map[string][]url.URL),
+	}
+	for _, rule := range rules {
+		domain, server, ok := strings.Cut(rule, "=")
+		if !ok || domain == "" {
+			return nil, errors.Errorf("rewrite rule %q should be domain=ip[:port]", rule)
+		}
+		u, err := ParseServer(server)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rewrite rule %q", rule)
+		}
+		domain = dns.CanonicalName(strings.TrimPrefix(domain, "."))
+		if _, ok := h.servers[domain]; !ok {
+			h.domains = append(h.domains, domain)
+		}
+		h.servers[domain] = append(h.servers[domain], *u)
+	}
+	return h, nil
+}
+
+func (h *rewriteHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if domain, ok := matchDomain(dns.CanonicalName(m.Question[0].Name), h.domains); ok {
+		ctx = clienturlctx.WithClientURLs(ctx, h.servers[domain])
+	}
+	next.Handler(ctx).ServeDNS(ctx, rw, m)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns
+
+import (
+	"context"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// ListenAndServe - serves the handler on the address over UDP and TCP until ctx is done. The UDP responses not fitting
+// the EDNS0 buffer size of the query, or 512 bytes without EDNS0, are truncated, so the client retries over TCP.
+func ListenAndServe(ctx context.Context, handler dnsutils.Handler, address string) {
+	for _, network := range []string{"udp", "tcp"} {
+		server := &dns.Server{
+			Addr: address,
+			Net:  network,
+			Handler: dns.HandlerFunc(func(rw dns.ResponseWriter, m *dns.Msg) {
+				if network == "udp" {
+					rw = &truncateResponseWriter{ResponseWriter: rw, size: udpSize(m)}
+				}
+				handler.ServeDNS(ctx, rw, m)
+			}),
+		}
+
+		go func() {
+			<-ctx.Done()
+			_ = server.Shutdown()
+		}()
+		go func() {
+			log.FromContext(ctx).Infof("local DNS server is listening on %s/%s", address, server.Net)
+			if err := server.ListenAndServe(); err != nil && ctx.Err() == nil {
+				log.FromContext(ctx).Errorf("failed to serve local DNS server on %s/%s: %v", address, server.Net, err.Error())
+			}
+		}()
+	}
+}
+
+// udpSize - returns the maximum size of the UDP response to the query
+func udpSize(m *dns.Msg) int {
+	if opt := m.IsEdns0(); opt != nil && opt.UDPSize() > dns.MinMsgSize {
+		return int(opt.UDPSize())
+	}
+	return dns.MinMsgSize
+}
+
+type truncateResponseWriter struct {
+	dns.ResponseWriter
+	size int
+}
+
+func (w *truncateResponseWriter) WriteMsg(m *dns.Msg) error {
+	m.Truncate(w.size)
+	return w.ResponseWriter.WriteMsg(m)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdns
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Upstream modes
+const (
+	// UpstreamFanout - the query is sent to all upstream servers at once, the first successful response wins
+	UpstreamFanout = "fanout"
+	// UpstreamSequential - the query is sent to the upstream servers one by one until one of them responds
+	UpstreamSequential = "sequential"
+)
+
+type upstreamHandler struct {
+	sequential bool
+	timeout    time.Duration
+	bufferSize uint16
+}
+
+// NewUpstreamHandler - returns a DNS handler sending the query to the client URLs from the context in the mode, it
+// should be the last in the chain. timeout limits a single upstream query, bufferSize is the EDNS0 UDP buffer size
+// advertised to the upstream servers.
+func NewUpstreamHandler(mode string, timeout time.Duration, bufferSize uint16) dnsutils.Handler {
+	return &upstreamHandler{
+		sequential: mode == UpstreamSequential,
+		timeout:    timeout,
+		bufferSize: bufferSize,
+	}
+}
+
+func (h *upstreamHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	logger := log.FromContext(ctx).WithField("upstreamHandler", "ServeDNS")
+
+	servers := clienturlctx.ClientURLs(ctx)
+	var resp *upstreamResponse
+	if h.sequential {
+		resp = h.sequentialExchange(ctx, servers, m)
+	} else {
+		resp = h.fanoutExchange(ctx, servers, m)
+	}
+	if resp == nil {
+		logger.Warnf("no upstream DNS server responded to %s", m.Question[0].Name)
+		dns.HandleFailed(rw, m)
+		return
+	}
+	setUpstream(ctx, resp.address)
+
+	if err := rw.WriteMsg(resp.msg); err != nil {
+		logger.Warnf("got an error during write the message: %v", err.Error())
+	}
+}
+
+// upstreamResponse - response of the upstream server at address, msg is nil if the server failed
+type upstreamResponse struct {
+	address string
+	msg     *dns.Msg
+}
+
+func (h *upstreamHandler) sequentialExchange(ctx context.Context, servers []url.URL, m *dns.Msg) *upstreamResponse {
+	var negative *upstreamResponse
+	for i := range servers {
+		switch resp := h.exchange(ctx, &servers[i], m); {
+		case resp.msg == nil:
+		case resp.msg.Rcode == dns.RcodeSuccess:
+			return resp
+		case resp.msg.Rcode == dns.RcodeNameError && negative == nil:
+			negative = resp
+		}
+	}
+	return negative
+}
+
+func (h *upstreamHandler) fanoutExchange(ctx context.Context, servers []url.URL, m *dns.Msg) *upstreamResponse {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make(chan *upstreamResponse, len(servers))
+	for i := range servers {
+		go func(server *url.URL) {
+			responses <- h.exchange(ctx, server, m)
+		}(&servers[i])
+	}
+
+	var negative *upstreamResponse
+	for range servers {
+		switch resp := <-responses; {
+		case resp.msg == nil:
+		case resp.msg.Rcode == dns.RcodeSuccess:
+			return resp
+		case resp.msg.Rcode == dns.RcodeNameError && negative == nil:
+			negative = resp
+		}
+	}
+	return negative
+}
+
+// exchange - returns the server response. The query is sent over UDP with at least bufferSize EDNS0 buffer, the
+// truncated response is retried over TCP.
+func (h *upstreamHandler) exchange(ctx context.Context, server *url.URL, m *dns.Msg) *upstreamResponse {
+	logger := log.FromContext(ctx).WithField("upstreamHandler", "exchange")
+
+	address := server.Host
+	if server.Port() == "" {
+		address = net.JoinHostPort(server.Hostname(), defaultPort)
+	}
+	result := &upstreamResponse{address: address}
+
+	query := m.Copy()
+	if opt := query.IsEdns0(); opt == nil {
+		query.SetEdns0(h.bufferSize, false)
+	} else if opt.UDPSize() < h.bufferSize {
+		opt.SetUDPSize(h.bufferSize)
+	}
+
+	resp, err := h.exchangeOver(ctx, "udp", address, query)
+	if err == nil && resp.Truncated {
+		if tcpResp, tcpErr := h.exchangeOver(ctx, "tcp", address, query); tcpErr == nil {
+			resp = tcpResp
+		} else {
+			logger.Debugf("DNS server %s failed over TCP, the truncated response is used: %v", address, tcpErr.Error())
+		}
+	}
+	if err != nil {
+		logger.Debugf("DNS server %s failed: %v", address, err.Error())
+		return result
+	}
+
+	// The client not using EDNS0 doesn't expect OPT record in the response
+	if m.IsEdns0() == nil {
+		removeOPT(resp)
+	}
+	result.msg = resp
+	return result
+}
+
+func (h *upstreamHandler) exchangeOver(ctx context.Context, network, address string, m *dns.Msg) (*dns.Msg, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	client := &dns.Client{Net: network, UDPSize: h.bufferSize}
+	resp, _, err := client.ExchangeContext(ctx, m, address)
+	return resp, errors.WithStack(err)
+}
+
+func removeOPT(m *dns.Msg) {
+	extra := m.Extra[:0]
+	for _, rr := range m.Extra {
+		if rr.Header().Rrtype != dns.TypeOPT {
+			extra = append(extra, rr)
+		}
+	}
+	m.Extra = extra
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type metricsClient struct {
+	metrics *Metrics
+}
+
+// NewClient - returns a client chain element counting the requests and measuring their latency
+func NewClient(m *Metrics) networkservice.NetworkServiceClient {
+	return &metricsClient{
+		metrics: m,
+	}
+}
+
+func (c *metricsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	networkService := request.GetConnection().GetNetworkService()
+
+	start := time.Now()
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	c.metrics.requestDuration.WithLabelValues(networkService).Observe(time.Since(start).Seconds())
+	c.metrics.requests.WithLabelValues(networkService, status.Code(err).String()).Inc()
+
+	return conn, err
+}
+
+func (c *metricsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
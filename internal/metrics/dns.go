@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+)
+
+type upstreamKey struct{}
+
+type dnsHandler struct {
+	metrics *Metrics
+}
+
+// NewDNSHandler - returns a DNS handler counting the queries, it should be right after the search domains handler, so
+// each search domain query is counted once as the cache hits and the upstream errors are
+func NewDNSHandler(m *Metrics) dnsutils.Handler {
+	return &dnsHandler{
+		metrics: m,
+	}
+}
+
+func (h *dnsHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	h.metrics.dnsQueries.Inc()
+	next.Handler(ctx).ServeDNS(ctx, rw, m)
+}
+
+type cacheDNSHandler struct {
+	metrics *Metrics
+}
+
+// NewCacheDNSHandler - returns a DNS handler counting the cache hits, it should be right before the cache handler
+func NewCacheDNSHandler(m *Metrics) dnsutils.Handler {
+	return &cacheDNSHandler{
+		metrics: m,
+	}
+}
+
+func (h *cacheDNSHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	upstream := new(bool)
+	next.Handler(ctx).ServeDNS(context.WithValue(ctx, upstreamKey{}, upstream), rw, m)
+
+	if !*upstream {
+		h.metrics.dnsCacheHits.Inc()
+	}
+}
+
+type upstreamDNSHandler struct {
+	metrics *Metrics
+}
+
+// NewUpstreamDNSHandler - returns a DNS handler counting the upstream errors, it should be right before the handler
+// querying the upstream servers
+func NewUpstreamDNSHandler(m *Metrics) dnsutils.Handler {
+	return &upstreamDNSHandler{
+		metrics: m,
+	}
+}
+
+func (h *upstreamDNSHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if upstream, ok := ctx.Value(upstreamKey{}).(*bool); ok {
+		*upstream = true
+	}
+	next.Handler(ctx).ServeDNS(ctx, &responseWriter{ResponseWriter: rw, metrics: h.metrics}, m)
+}
+
+type responseWriter struct {
+	dns.ResponseWriter
+	metrics *Metrics
+}
+
+func (w *responseWriter) WriteMsg(m *dns.Msg) error {
+	if m.Rcode == dns.RcodeServerFailure {
+		w.metrics.dnsUpstreamErrors.Inc()
+	}
+	return w.ResponseWriter.WriteMsg(m)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides Prometheus metrics of the NSC
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+)
+
+const (
+	namespace = "nsc"
+
+	networkServiceLabel = "network_service"
+	idLabel             = "id"
+	codeLabel           = "code"
+)
+
+// Metrics - Prometheus metrics of the NSC
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests          *prometheus.CounterVec
+	requestDuration   *prometheus.HistogramVec
+	connectionState   *prometheus.GaugeVec
+	heals             *prometheus.CounterVec
+	reselects         *prometheus.CounterVec
+	monitorReconnects *prometheus.CounterVec
+
+	dnsQueries        prometheus.Counter
+	dnsCacheHits      prometheus.Counter
+	dnsUpstreamErrors prometheus.Counter
+}
+
+// New - creates Metrics registered in a new registry together with the Go and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of Network Service request attempts by gRPC status code",
+		}, []string{networkServiceLabel, codeLabel}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Network Service request latency",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		}, []string{networkServiceLabel}),
+		connectionState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "connection_up",
+			Help:      "Connection state, 1 if the connection is established and is not reported DOWN",
+		}, []string{networkServiceLabel, idLabel}),
+		heals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "heals_total",
+			Help:      "Number of connections healed after DOWN with the same NSE",
+		}, []string{networkServiceLabel}),
+		reselects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reselects_total",
+			Help:      "Number of connections healed after DOWN with another NSE",
+		}, []string{networkServiceLabel}),
+		monitorReconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "monitor_reconnects_total",
+			Help:      "Number of NSMgr monitor stream reconnects",
+		}, []string{networkServiceLabel}),
+		dnsQueries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "dns",
+			Name:      "queries_total",
+			Help:      "Number of queries served by the local DNS server, each search domain query is counted",
+		}),
+		dnsCacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "dns",
+			Name:      "cache_hits_total",
+			Help:      "Number of queries answered by the local DNS server without the upstream servers",
+		}),
+		dnsUpstreamErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "dns",
+			Name:      "upstream_errors_total",
+			Help:      "Number of queries failed by the upstream DNS servers",
+		}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.connectionState,
+		m.heals,
+		m.reselects,
+		m.monitorReconnects,
+		m.dnsQueries,
+		m.dnsCacheHits,
+		m.dnsUpstreamErrors,
+	)
+	return m
+}
+
+// Handler - returns the /metrics handler
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Watch - starts updating the connection metrics from the broker events until ctx is done
+func (m *Metrics) Watch(ctx context.Context, broker *connection.EventBroker) {
+	events := broker.Subscribe(ctx)
+	go func() {
+		for event := range events {
+			m.update(event)
+		}
+	}()
+}
+
+func (m *Metrics) update(event connection.Event) {
+	networkService := event.Connection.GetNetworkService()
+	switch event.Type {
+	case connection.EventEstablished:
+		m.connectionState.WithLabelValues(networkService, event.ID).Set(1)
+	case connection.EventHealed:
+		m.heals.WithLabelValues(networkService).Inc()
+		m.connectionState.WithLabelValues(networkService, event.ID).Set(1)
+	case connection.EventReselected:
+		m.reselects.WithLabelValues(networkService).Inc()
+		m.connectionState.WithLabelValues(networkService, event.ID).Set(1)
+	case connection.EventDown:
+		m.connectionState.WithLabelValues(networkService, event.ID).Set(0)
+	case connection.EventMonitorReconnected:
+		m.monitorReconnects.WithLabelValues(networkService).Inc()
+	case connection.EventClosed:
+		m.connectionState.DeleteLabelValues(networkService, event.ID)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	dnschain "github.com/networkservicemesh/sdk/pkg/tools/dnsutils/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/searches"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/metrics"
+)
+
+func TestClient_Requests(t *testing.T) {
+	m := metrics.New()
+	client := chain.NewNetworkServiceClient(metrics.NewClient(m), null.NewClient())
+
+	_, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "nsc-0", NetworkService: "my-service"},
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `nsc_requests_total{code="OK",network_service="my-service"} 1`)
+	require.Contains(t, string(body), `nsc_request_duration_seconds_count{network_service="my-service"} 1`)
+}
+
+type failedResponseWriter struct {
+	dns.ResponseWriter
+}
+
+func (w *failedResponseWriter) WriteMsg(*dns.Msg) error {
+	return nil
+}
+
+type failedHandler struct{}
+
+func (failedHandler) ServeDNS(_ context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	dns.HandleFailed(rw, m)
+}
+
+func TestDNSHandler_SearchDomains(t *testing.T) {
+	m := metrics.New()
+	handler := dnschain.NewDNSHandler(
+		searches.NewDNSHandler(),
+		metrics.NewDNSHandler(m),
+		metrics.NewUpstreamDNSHandler(m),
+		failedHandler{},
+	)
+
+	ctx := searches.WithSearchDomains(context.Background(), []string{"example.org"})
+	handler.ServeDNS(ctx, new(failedResponseWriter), new(dns.Msg).SetQuestion("db.", dns.TypeA))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	// The query and the search domain query are counted alike
+	require.Contains(t, string(body), "nsc_dns_queries_total 2")
+	require.Contains(t, string(body), "nsc_dns_upstream_errors_total 2")
+}
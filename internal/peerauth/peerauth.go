@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package peerauth - authorizes the SPIFFE ID of the TLS peer
+package peerauth
+
+import (
+	"crypto/x509"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+)
+
+const spiffeScheme = "spiffe://"
+
+type pattern struct {
+	trustDomain spiffeid.TrustDomain
+	// path - path.Match pattern of the SPIFFE ID path, empty matches any ID of the trust domain
+	path string
+}
+
+func (p *pattern) match(id spiffeid.ID) bool {
+	if id.TrustDomain() != p.trustDomain {
+		return false
+	}
+	if p.path == "" {
+		return true
+	}
+	matched, _ := path.Match(p.path, id.Path())
+	return matched
+}
+
+// NewAuthorizer - returns an authorizer accepting the peer SPIFFE IDs matching any of the patterns, any SPIFFE ID is
+// accepted if there are no patterns. The pattern is one of:
+//   - SPIFFE ID: spiffe://example.org/ns/nsm-system/sa/nsmgr
+//   - trust domain: spiffe://example.org
+//   - path pattern: spiffe://example.org/ns/*/sa/nsmgr, see path.Match for the syntax
+func NewAuthorizer(patterns []string) (tlsconfig.Authorizer, error) {
+	if len(patterns) == 0 {
+		return tlsconfig.AuthorizeAny(), nil
+	}
+
+	parsed := make([]*pattern, 0, len(patterns))
+	for _, s := range patterns {
+		p, err := parsePattern(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+
+	return func(id spiffeid.ID, _ [][]*x509.Certificate) error {
+		for _, p := range parsed {
+			if p.match(id) {
+				return nil
+			}
+		}
+		return errors.Errorf("peer SPIFFE ID %q is not allowed, expected one of: %s", id.String(), strings.Join(patterns, ", "))
+	}, nil
+}
+
+func parsePattern(s string) (*pattern, error) {
+	if !strings.HasPrefix(s, spiffeScheme) {
+		return nil, errors.Errorf("SPIFFE ID pattern %q should start with %s", s, spiffeScheme)
+	}
+	trustDomain, idPath, _ := strings.Cut(strings.TrimPrefix(s, spiffeScheme), "/")
+
+	td, err := spiffeid.TrustDomainFromString(trustDomain)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid trust domain of SPIFFE ID pattern %q", s)
+	}
+	p := &pattern{trustDomain: td}
+	if idPath != "" {
+		p.path = "/" + idPath
+		if _, err := path.Match(p.path, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid path of SPIFFE ID pattern %q", s)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peerauth_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/peerauth"
+)
+
+func TestNewAuthorizer(t *testing.T) {
+	authorizer, err := peerauth.NewAuthorizer([]string{
+		"spiffe://example.org/ns/*/sa/nsmgr",
+		"spiffe://example.com",
+	})
+	require.NoError(t, err)
+
+	for id, allowed := range map[string]bool{
+		"spiffe://example.org/ns/nsm-system/sa/nsmgr": true,
+		"spiffe://example.org/ns/default/sa/nsmgr":    true,
+		"spiffe://example.org/ns/default/sa/nse":      false,
+		"spiffe://example.org/ns/a/b/sa/nsmgr":        false,
+		"spiffe://example.com/ns/default/sa/nse":      true,
+		"spiffe://example.net/ns/default/sa/nsmgr":    false,
+	} {
+		err := authorizer(spiffeid.RequireFromString(id), nil)
+		if allowed {
+			require.NoError(t, err, id)
+		} else {
+			require.ErrorContains(t, err, "is not allowed", id)
+		}
+	}
+}
+
+func TestNewAuthorizer_InvalidPattern(t *testing.T) {
+	for _, pattern := range []string{
+		"example.org/ns/nsm-system/sa/nsmgr",
+		"spiffe://EXAMPLE.org",
+		"spiffe://example.org/ns/[",
+	} {
+		_, err := peerauth.NewAuthorizer([]string{pattern})
+		require.Error(t, err, pattern)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package perservice provides a chain element passing requests to the client configured for the Network Service
+package perservice
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Client - chain element passing requests to the client stored for the connection ID or to the default client
+type Client struct {
+	defaultClient networkservice.NetworkServiceClient
+	clients       genericsync.Map[string, networkservice.NetworkServiceClient]
+}
+
+// NewClient - creates a Client, defaultClient is used for the connections with no client stored
+func NewClient(defaultClient networkservice.NetworkServiceClient) *Client {
+	return &Client{
+		defaultClient: defaultClient,
+	}
+}
+
+// Store - sets client for the connection ID
+func (c *Client) Store(id string, client networkservice.NetworkServiceClient) {
+	c.clients.Store(id, client)
+}
+
+// Delete - removes client for the connection ID
+func (c *Client) Delete(id string) {
+	c.clients.Delete(id)
+}
+
+// Request - calls Request of the client stored for the connection ID
+func (c *Client) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	return c.client(request.GetConnection().GetId()).Request(ctx, request, opts...)
+}
+
+// Close - calls Close of the client stored for the connection ID
+func (c *Client) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return c.client(conn.GetId()).Close(ctx, conn, opts...)
+}
+
+func (c *Client) client(id string) networkservice.NetworkServiceClient {
+	if client, ok := c.clients.Load(id); ok {
+		return client
+	}
+	return c.defaultClient
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolvconf - points resolv.conf at the local DNS server and restores the original one on shutdown
+package resolvconf
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/fileutils"
+)
+
+const backupSuffix = ".nsc-backup"
+
+// Config - resolv.conf content
+type Config struct {
+	Nameservers []string
+	Searches    []string
+	Options     []string
+}
+
+// Parse - parses nameserver, search, domain and options lines of resolv.conf, other lines are skipped
+func Parse(data []byte) *Config {
+	c := new(Config)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			c.Nameservers = append(c.Nameservers, fields[1])
+		case "search", "domain":
+			// The last search or domain line wins
+			c.Searches = fields[1:]
+		case "options":
+			c.Options = append(c.Options, fields[1:]...)
+		}
+	}
+	return c
+}
+
+// Bytes - returns resolv.conf content of the config
+func (c *Config) Bytes() []byte {
+	var b bytes.Buffer
+	for _, nameserver := range c.Nameservers {
+		b.WriteString("nameserver " + nameserver + "\n")
+	}
+	if len(c.Searches) != 0 {
+		b.WriteString("search " + strings.Join(c.Searches, " ") + "\n")
+	}
+	if len(c.Options) != 0 {
+		b.WriteString("options " + strings.Join(c.Options, " ") + "\n")
+	}
+	return b.Bytes()
+}
+
+// Manager - manages resolv.conf at path, the local DNS server goes first followed by the original nameservers, the
+// search domains of the connections are merged with the original ones
+type Manager struct {
+	path          string
+	backupPath    string
+	localIP       string
+	dnsConfigsMap *genericsync.Map[string, []*networkservice.DNSConfig]
+
+	mu       sync.Mutex
+	original []byte
+	parsed   *Config
+}
+
+// NewManager - creates a Manager of resolv.conf at path pointing to the local DNS server at localIP
+func NewManager(path, localIP string, dnsConfigsMap *genericsync.Map[string, []*networkservice.DNSConfig]) *Manager {
+	return &Manager{
+		path:          path,
+		backupPath:    path + backupSuffix,
+		localIP:       localIP,
+		dnsConfigsMap: dnsConfigsMap,
+	}
+}
+
+// Apply - backs up the original resolv.conf and writes the managed resolv.conf. The backup left by the previous run is
+// treated as the original.
+func (m *Manager) Apply() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	original, err := os.ReadFile(filepath.Clean(m.backupPath))
+	switch {
+	case os.IsNotExist(err):
+		if original, err = os.ReadFile(filepath.Clean(m.path)); err != nil {
+			return errors.Wrapf(err, "failed to read %s", m.path)
+		}
+		if err = fileutils.WriteFileAtomic(m.backupPath, original, 0o644); err != nil {
+			return errors.Wrapf(err, "failed to back up %s", m.path)
+		}
+	case err != nil:
+		return errors.Wrapf(err, "failed to read %s", m.backupPath)
+	}
+	m.original = original
+	m.parsed = Parse(original)
+
+	return m.write()
+}
+
+// Fallback - returns the original nameservers except the local DNS server, they are used by the local DNS server if
+// the DNS servers of the connections fail
+func (m *Manager) Fallback() []string {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.parsed == nil {
+		return nil
+	}
+	return m.nameservers()
+}
+
+// Watch - starts updating the search domains of resolv.conf from the broker events until ctx is done
+func (m *Manager) Watch(ctx context.Context, broker *connection.EventBroker) {
+	if m == nil {
+		return
+	}
+
+	events := broker.Subscribe(ctx)
+
+	go func() {
+		for event := range events {
+			switch event.Type {
+			case connection.EventEstablished, connection.EventUpdated, connection.EventHealed, connection.EventReselected, connection.EventClosed:
+			default:
+				continue
+			}
+			m.mu.Lock()
+			err := m.write()
+			m.mu.Unlock()
+			if err != nil {
+				log.FromContext(ctx).Errorf("failed to update %s: %v", m.path, err.Error())
+			}
+		}
+	}()
+}
+
+// Restore - writes the original resolv.conf back and removes the backup
+func (m *Manager) Restore() error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.original == nil {
+		return nil
+	}
+	if err := writeFile(m.path, m.original); err != nil {
+		return errors.Wrapf(err, "failed to restore %s", m.path)
+	}
+	m.original = nil
+	return errors.Wrapf(os.Remove(m.backupPath), "failed to remove %s", m.backupPath)
+}
+
+func (m *Manager) write() error {
+	if m.original == nil {
+		return nil
+	}
+
+	managed := &Config{
+		Nameservers: []string{m.localIP},
+		Searches:    merge(m.connectionSearches(), m.parsed.Searches),
+		Options:     m.parsed.Options,
+	}
+	managed.Nameservers = append(managed.Nameservers, m.nameservers()...)
+	return writeFile(m.path, managed.Bytes())
+}
+
+// nameservers - returns the original nameservers except the local DNS server
+func (m *Manager) nameservers() []string {
+	var nameservers []string
+	for _, nameserver := range m.parsed.Nameservers {
+		if nameserver != m.localIP {
+			nameservers = append(nameservers, nameserver)
+		}
+	}
+	return nameservers
+}
+
+// connectionSearches - returns the search domains of the connections ordered by the connection ID
+func (m *Manager) connectionSearches() []string {
+	var ids []string
+	m.dnsConfigsMap.Range(func(id string, _ []*networkservice.DNSConfig) bool {
+		ids = append(ids, id)
+		return true
+	})
+	sort.Strings(ids)
+
+	var searches []string
+	for _, id := range ids {
+		configs, _ := m.dnsConfigsMap.Load(id)
+		for _, c := range configs {
+			searches = append(searches, c.GetSearchDomains()...)
+		}
+	}
+	return searches
+}
+
+func merge(lists ...[]string) []string {
+	var merged []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, s := range list {
+			if !seen[s] {
+				seen[s] = true
+				merged = append(merged, s)
+			}
+		}
+	}
+	return merged
+}
+
+// writeFile - overwrites the file in place, resolv.conf is usually bind mounted into the container, so it can't be
+// replaced by rename
+func writeFile(path string, data []byte) error {
+	// #nosec G306 - resolv.conf is readable by everyone
+	return errors.WithStack(os.WriteFile(path, data, 0o644))
+}
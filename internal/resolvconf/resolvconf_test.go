@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvconf_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/resolvconf"
+)
+
+const original = `# generated by kubelet
+nameserver 10.96.0.10
+search default.svc.cluster.local svc.cluster.local cluster.local
+options ndots:5
+`
+
+func TestManager(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "resolv.conf")
+	require.NoError(t, os.WriteFile(path, []byte(original), 0o600))
+
+	dnsConfigsMap := new(genericsync.Map[string, []*networkservice.DNSConfig])
+	broker := connection.NewEventBroker()
+	m := resolvconf.NewManager(path, "127.0.0.1", dnsConfigsMap)
+	require.NoError(t, m.Apply())
+	m.Watch(ctx, broker)
+
+	require.Equal(t, []string{"10.96.0.10"}, m.Fallback())
+
+	requireContent(t, path, `nameserver 127.0.0.1
+nameserver 10.96.0.10
+search default.svc.cluster.local svc.cluster.local cluster.local
+options ndots:5
+`)
+
+	dnsConfigsMap.Store("nsc-0", []*networkservice.DNSConfig{{
+		DnsServerIps:  []string{"172.16.0.1"},
+		SearchDomains: []string{"my-service.example.org", "cluster.local"},
+	}})
+	broker.Publish(ctx, connection.Event{Type: connection.EventEstablished, ID: "nsc-0"})
+
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(filepath.Clean(path))
+		return err == nil && string(data) == `nameserver 127.0.0.1
+nameserver 10.96.0.10
+search my-service.example.org cluster.local default.svc.cluster.local svc.cluster.local
+options ndots:5
+`
+	}, time.Second, 10*time.Millisecond)
+
+	// The backup left by a crashed run is used as the original resolv.conf
+	restarted := resolvconf.NewManager(path, "127.0.0.1", dnsConfigsMap)
+	require.NoError(t, restarted.Apply())
+	require.Equal(t, []string{"10.96.0.10"}, restarted.Fallback())
+
+	require.NoError(t, m.Restore())
+	requireContent(t, path, original)
+	_, err := os.Stat(path + ".nsc-backup")
+	require.True(t, os.IsNotExist(err))
+}
+
+func requireContent(t *testing.T, path, expected string) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err)
+	require.Equal(t, expected, string(data))
+}
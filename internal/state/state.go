@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package state provides the file store of the established connections
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/fileutils"
+)
+
+const fileMode = 0o600
+
+// File - connection.Store keeping the connections in a JSON file
+type File struct {
+	path        string
+	mu          sync.Mutex
+	connections map[string]*networkservice.Connection
+}
+
+// NewFile - creates a File loading the connections stored at path, the file is created on the first Save
+func NewFile(path string) (*File, error) {
+	f := &File{
+		path:        path,
+		connections: make(map[string]*networkservice.Connection),
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read state file %s", path)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse state file %s", path)
+	}
+	for id, rawConn := range raw {
+		conn := new(networkservice.Connection)
+		if err := protojson.Unmarshal(rawConn, conn); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse connection %s in state file %s", id, path)
+		}
+		f.connections[id] = conn
+	}
+	return f, nil
+}
+
+// Load - returns the stored connection with the id or nil
+func (f *File) Load(id string) *networkservice.Connection {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	conn, ok := f.connections[id]
+	if !ok {
+		return nil
+	}
+	return conn.Clone()
+}
+
+// Save - stores the connection with the id and writes the file
+func (f *File) Save(id string, conn *networkservice.Connection) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.connections[id] = conn.Clone()
+	return f.write()
+}
+
+// Delete - deletes the connection with the id and writes the file
+func (f *File) Delete(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.connections[id]; !ok {
+		return nil
+	}
+	delete(f.connections, id)
+	return f.write()
+}
+
+func (f *File) write() error {
+	raw := make(map[string]json.RawMessage, len(f.connections))
+	for id, conn := range f.connections {
+		data, err := protojson.Marshal(conn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal connection %s", id)
+		}
+		raw[id] = data
+	}
+
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal state")
+	}
+	return errors.Wrapf(fileutils.WriteFileAtomic(f.path, data, fileMode), "failed to write state file %s", f.path)
+}
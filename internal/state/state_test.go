@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/state"
+)
+
+func TestFile_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	f, err := state.NewFile(path)
+	require.NoError(t, err)
+	require.Nil(t, f.Load("nsc-0"))
+
+	conn := &networkservice.Connection{
+		Id:                         "nsc-0",
+		NetworkService:             "my-service",
+		NetworkServiceEndpointName: "nse-1",
+		Context: &networkservice.ConnectionContext{
+			IpContext: &networkservice.IPContext{
+				SrcIpAddrs: []string{"172.16.0.2/32"},
+			},
+		},
+	}
+	require.NoError(t, f.Save("nsc-0", conn))
+	require.NoError(t, f.Save("nsc-1", &networkservice.Connection{Id: "nsc-1"}))
+	require.NoError(t, f.Delete("nsc-1"))
+
+	// The restarted client loads the connections from the file
+	f, err = state.NewFile(path)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(conn, f.Load("nsc-0")))
+	require.Nil(t, f.Load("nsc-1"))
+}
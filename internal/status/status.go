@@ -0,0 +1,244 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package status provides the connection status file for the containers sharing the pod
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/fileutils"
+)
+
+const (
+	// StateRequested - the Network Service is being requested
+	StateRequested = "REQUESTED"
+	// StateUp - the connection is established
+	StateUp = "UP"
+	// StateDown - the connection is reported DOWN by NSMgr
+	StateDown = "DOWN"
+	// StateHealing - the connection reported DOWN is being healed, it is reported by the webhooks only, the status file
+	// keeps StateDown
+	StateHealing = "HEALING"
+	// StateClosed - the connection is closed, such connections are not listed in the status file
+	StateClosed = "CLOSED"
+
+	fileMode = 0o644
+)
+
+// Status - content of the status file
+type Status struct {
+	Connections []*Connection `json:"connections"`
+}
+
+// Connection - status of the connection to the Network Service
+type Connection struct {
+	ID                         string       `json:"id"`
+	URL                        string       `json:"url"`
+	NetworkService             string       `json:"networkService,omitempty"`
+	NetworkServiceEndpointName string       `json:"networkServiceEndpointName,omitempty"`
+	Mechanism                  string       `json:"mechanism,omitempty"`
+	InterfaceName              string       `json:"interfaceName,omitempty"`
+	SrcIPs                     []string     `json:"srcIPs,omitempty"`
+	DstIPs                     []string     `json:"dstIPs,omitempty"`
+	SrcRoutes                  []*Route     `json:"srcRoutes,omitempty"`
+	DstRoutes                  []*Route     `json:"dstRoutes,omitempty"`
+	DNSConfigs                 []*DNSConfig `json:"dnsConfigs,omitempty"`
+	State                      string       `json:"state"`
+}
+
+// Route - route of the connection
+type Route struct {
+	Prefix  string `json:"prefix"`
+	NextHop string `json:"nextHop,omitempty"`
+}
+
+// DNSConfig - DNS config of the connection
+type DNSConfig struct {
+	DNSServerIPs  []string `json:"dnsServerIPs,omitempty"`
+	SearchDomains []string `json:"searchDomains,omitempty"`
+}
+
+// Writer - writes the status file on every connection event
+type Writer struct {
+	path           string
+	keepOnShutdown bool
+	mu             sync.Mutex
+	connections    map[string]*Connection
+}
+
+// Option - Writer option
+type Option func(w *Writer)
+
+// WithKeepOnShutdown - keeps the connections left in the file on shutdown, for the connections left open
+func WithKeepOnShutdown() Option {
+	return func(w *Writer) {
+		w.keepOnShutdown = true
+	}
+}
+
+// NewWriter - creates a Writer of the status file at path, the file is YAML if it has .yaml or .yml extension and
+// JSON otherwise
+func NewWriter(path string, opts ...Option) *Writer {
+	w := &Writer{
+		path:        path,
+		connections: make(map[string]*Connection),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Watch - writes the initial status file and starts updating it from the broker events until ctx is done. After that
+// the connections left are removed from the file unless WithKeepOnShutdown is set, the returned channel is closed
+// once it is written.
+func (w *Writer) Watch(ctx context.Context, broker *connection.EventBroker) <-chan struct{} {
+	logger := log.FromContext(ctx).WithField("statusFile", w.path)
+
+	events := broker.Subscribe(ctx)
+	if err := w.write(); err != nil {
+		logger.Errorf("failed to write status file: %v", err.Error())
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range events {
+			if !w.update(event) {
+				continue
+			}
+			if err := w.write(); err != nil {
+				logger.Errorf("failed to write status file: %v", err.Error())
+			}
+		}
+		if w.keepOnShutdown {
+			return
+		}
+
+		// The connections are closed on shutdown, even if their close events are missed
+		w.mu.Lock()
+		w.connections = make(map[string]*Connection)
+		w.mu.Unlock()
+		if err := w.write(); err != nil {
+			logger.Errorf("failed to write status file: %v", err.Error())
+		}
+	}()
+	return done
+}
+
+func (w *Writer) update(event connection.Event) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch event.Type {
+	case connection.EventRequested:
+		w.connections[event.ID] = &Connection{
+			ID:    event.ID,
+			URL:   event.NetworkService,
+			State: StateRequested,
+		}
+	case connection.EventEstablished, connection.EventHealed, connection.EventReselected, connection.EventUpdated:
+		w.connections[event.ID] = NewConnection(event, StateUp)
+	case connection.EventDown:
+		w.connections[event.ID] = NewConnection(event, StateDown)
+	case connection.EventClosed:
+		delete(w.connections, event.ID)
+	default:
+		return false
+	}
+	return true
+}
+
+// NewConnection - returns the status of the connection from the event
+func NewConnection(event connection.Event, state string) *Connection {
+	conn := event.Connection
+	ipContext := conn.GetContext().GetIpContext()
+
+	c := &Connection{
+		ID:                         event.ID,
+		URL:                        event.NetworkService,
+		NetworkService:             conn.GetNetworkService(),
+		NetworkServiceEndpointName: conn.GetNetworkServiceEndpointName(),
+		Mechanism:                  conn.GetMechanism().GetType(),
+		InterfaceName:              conn.GetMechanism().GetParameters()[common.InterfaceNameKey],
+		SrcIPs:                     ipContext.GetSrcIpAddrs(),
+		DstIPs:                     ipContext.GetDstIpAddrs(),
+		SrcRoutes:                  newRoutes(ipContext.GetSrcRoutes()),
+		DstRoutes:                  newRoutes(ipContext.GetDstRoutes()),
+		State:                      state,
+	}
+	for _, dnsConfig := range conn.GetContext().GetDnsContext().GetConfigs() {
+		c.DNSConfigs = append(c.DNSConfigs, &DNSConfig{
+			DNSServerIPs:  dnsConfig.GetDnsServerIps(),
+			SearchDomains: dnsConfig.GetSearchDomains(),
+		})
+	}
+	return c
+}
+
+func newRoutes(routes []*networkservice.Route) []*Route {
+	var result []*Route
+	for _, route := range routes {
+		result = append(result, &Route{
+			Prefix:  route.GetPrefix(),
+			NextHop: route.GetNextHop(),
+		})
+	}
+	return result
+}
+
+// write - writes the status file atomically
+func (w *Writer) write() error {
+	w.mu.Lock()
+	status := &Status{
+		Connections: make([]*Connection, 0, len(w.connections)),
+	}
+	for _, c := range w.connections {
+		status.Connections = append(status.Connections, c)
+	}
+	w.mu.Unlock()
+
+	sort.Slice(status.Connections, func(i, j int) bool {
+		return status.Connections[i].ID < status.Connections[j].ID
+	})
+
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(w.path)) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(status)
+	default:
+		data, err = json.MarshalIndent(status, "", "  ")
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal status")
+	}
+
+	return errors.Wrap(fileutils.WriteFileAtomic(w.path, data, fileMode), "failed to write status file")
+}
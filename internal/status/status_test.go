@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/status"
+)
+
+func readStatus(t *testing.T, path string) *status.Status {
+	data, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err)
+	s := new(status.Status)
+	require.NoError(t, json.Unmarshal(data, s))
+	return s
+}
+
+func TestWriter_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "status.json")
+	broker := connection.NewEventBroker()
+	done := status.NewWriter(path).Watch(ctx, broker)
+	require.Empty(t, readStatus(t, path).Connections)
+
+	conn := &networkservice.Connection{
+		Id:                         "nsc-0",
+		NetworkService:             "my-service",
+		NetworkServiceEndpointName: "nse-1",
+		Context: &networkservice.ConnectionContext{
+			IpContext: &networkservice.IPContext{
+				SrcIpAddrs: []string{"172.16.0.2/32"},
+				DstRoutes:  []*networkservice.Route{{Prefix: "172.16.0.0/24"}},
+			},
+		},
+	}
+	broker.Publish(ctx, connection.Event{Type: connection.EventRequested, ID: "nsc-0", NetworkService: "kernel://my-service/nsm-1"})
+	broker.Publish(ctx, connection.Event{Type: connection.EventEstablished, ID: "nsc-0", NetworkService: "kernel://my-service/nsm-1", Connection: conn})
+
+	require.Eventually(t, func() bool {
+		s := readStatus(t, path)
+		return len(s.Connections) == 1 && s.Connections[0].State == status.StateUp
+	}, time.Second, 10*time.Millisecond)
+
+	c := readStatus(t, path).Connections[0]
+	require.Equal(t, "my-service", c.NetworkService)
+	require.Equal(t, "nse-1", c.NetworkServiceEndpointName)
+	require.Equal(t, []string{"172.16.0.2/32"}, c.SrcIPs)
+	require.Equal(t, "172.16.0.0/24", c.DstRoutes[0].Prefix)
+
+	broker.Publish(ctx, connection.Event{Type: connection.EventClosed, ID: "nsc-0", Connection: conn})
+	require.Eventually(t, func() bool {
+		return len(readStatus(t, path).Connections) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// The file is written once more on shutdown
+	cancel()
+	<-done
+}
+
+func TestWriter_Shutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "status.json")
+	broker := connection.NewEventBroker()
+	done := status.NewWriter(path).Watch(ctx, broker)
+
+	broker.Publish(ctx, connection.Event{Type: connection.EventRequested, ID: "nsc-0", NetworkService: "kernel://my-service/nsm-1"})
+	require.Eventually(t, func() bool {
+		return len(readStatus(t, path).Connections) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// No close event is received for the connection
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "status file is not written on shutdown")
+	}
+	require.Empty(t, readStatus(t, path).Connections)
+}
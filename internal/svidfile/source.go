@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package svidfile - X.509 SVID source reading the SVID and the trust bundle from PEM files
+package svidfile
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// Source - x509svid.Source and x509bundle.Source reading the PEM files, the files are checked every interval and
+// reloaded when they change. The bundle is used for the trust domain of the SVID only.
+type Source struct {
+	certPath   string
+	keyPath    string
+	bundlePath string
+
+	mu     sync.RWMutex
+	svid   *x509svid.SVID
+	bundle *x509bundle.Bundle
+}
+
+// NewSource - loads the SVID certificates chain, its private key and the trust bundle and starts reloading them until
+// ctx is done
+func NewSource(ctx context.Context, certPath, keyPath, bundlePath string, interval time.Duration) (*Source, error) {
+	s := &Source{
+		certPath:   certPath,
+		keyPath:    keyPath,
+		bundlePath: bundlePath,
+	}
+	hash := s.hash()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		logger := log.FromContext(ctx).WithField("svidFile", certPath)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			newHash := s.hash()
+			if newHash == hash {
+				continue
+			}
+			// The files may be written one by one, so the failed load is retried on the next check
+			if err := s.load(); err != nil {
+				logger.Warnf("failed to reload SVID files, the previous SVID is used: %v", err.Error())
+				continue
+			}
+			hash = newHash
+			logger.Infof("SVID files are reloaded, sVID: %q", s.svid.ID)
+		}
+	}()
+
+	return s, nil
+}
+
+// GetX509SVID - returns the current SVID
+func (s *Source) GetX509SVID() (*x509svid.SVID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.svid, nil
+}
+
+// GetX509BundleForTrustDomain - returns the current bundle if trustDomain is the SVID trust domain
+func (s *Source) GetX509BundleForTrustDomain(trustDomain spiffeid.TrustDomain) (*x509bundle.Bundle, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if trustDomain != s.bundle.TrustDomain() {
+		return nil, errors.Errorf("no X.509 bundle for trust domain %q", trustDomain)
+	}
+	return s.bundle, nil
+}
+
+func (s *Source) load() error {
+	svid, err := x509svid.Load(s.certPath, s.keyPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load SVID from %s and %s", s.certPath, s.keyPath)
+	}
+	bundle, err := x509bundle.Load(svid.ID.TrustDomain(), s.bundlePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load bundle from %s", s.bundlePath)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.svid = svid
+	s.bundle = bundle
+	return nil
+}
+
+// hash - returns the hash of all files, the missing files are skipped
+func (s *Source) hash() [sha256.Size]byte {
+	h := sha256.New()
+	for _, path := range []string{s.certPath, s.keyPath, s.bundlePath} {
+		data, _ := os.ReadFile(filepath.Clean(path))
+		_, _ = h.Write(data)
+	}
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svidfile_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/svidfile"
+)
+
+func TestSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "tls.crt")
+	keyPath := filepath.Join(dir, "tls.key")
+	bundlePath := filepath.Join(dir, "ca.crt")
+
+	writeSVID(t, "spiffe://example.org/ns/default/sa/nsc", certPath, keyPath, bundlePath)
+	source, err := svidfile.NewSource(ctx, certPath, keyPath, bundlePath, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	svid, err := source.GetX509SVID()
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/ns/default/sa/nsc", svid.ID.String())
+
+	bundle, err := source.GetX509BundleForTrustDomain(spiffeid.RequireTrustDomainFromString("example.org"))
+	require.NoError(t, err)
+	require.Len(t, bundle.X509Authorities(), 1)
+	_, err = source.GetX509BundleForTrustDomain(spiffeid.RequireTrustDomainFromString("example.com"))
+	require.Error(t, err)
+
+	writeSVID(t, "spiffe://example.org/ns/default/sa/nsc-2", certPath, keyPath, bundlePath)
+	require.Eventually(t, func() bool {
+		svid, err = source.GetX509SVID()
+		return err == nil && svid.ID.String() == "spiffe://example.org/ns/default/sa/nsc-2"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewSource_InvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	_, err := svidfile.NewSource(context.Background(),
+		filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt"), time.Second)
+	require.Error(t, err)
+}
+
+// writeSVID - writes a new CA certificate to bundlePath and the SVID of id signed by it to certPath and keyPath
+func writeSVID(t *testing.T, id, certPath, keyPath, bundlePath string) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	uri, err := url.Parse(id)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		URIs:         []*url.URL{uri},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(bundlePath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+)
+
+// Option - Notifier option
+type Option func(n *Notifier)
+
+// WithSecret - sets a key of the HMAC-SHA256 signature sent in the SignatureHeader
+func WithSecret(secret string) Option {
+	return func(n *Notifier) {
+		n.secret = []byte(secret)
+	}
+}
+
+// WithTimeout - sets a timeout of a single delivery attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(n *Notifier) {
+		n.client.Timeout = timeout
+	}
+}
+
+// WithMaxAttempts - sets a maximum number of delivery attempts of an event to a target
+func WithMaxAttempts(maxAttempts int) Option {
+	return func(n *Notifier) {
+		n.maxAttempts = maxAttempts
+	}
+}
+
+// WithBackoff - sets a backoff between failed delivery attempts
+func WithBackoff(retryBackoff backoff.Backoff) Option {
+	return func(n *Notifier) {
+		n.backoff = retryBackoff
+	}
+}
+
+// WithQueueSize - sets a maximum number of queued events, the new events are dropped if the queue is full
+func WithQueueSize(queueSize int) Option {
+	return func(n *Notifier) {
+		n.queueSize = queueSize
+	}
+}
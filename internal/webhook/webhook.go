@@ -0,0 +1,253 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook notifies HTTP targets about the connection lifecycle events
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/status"
+)
+
+const (
+	// EventHeader - header with the event type
+	EventHeader = "X-NSC-Event"
+	// SignatureHeader - header with "sha256=" followed by the hex encoded HMAC-SHA256 of the body
+	SignatureHeader = "X-NSC-Signature"
+)
+
+// Webhook events
+const (
+	// EventRequested - the Network Service is being requested
+	EventRequested = "requested"
+	// EventEstablished - the connection is established
+	EventEstablished = "established"
+	// EventHealing - the connection reported DOWN starts healing
+	EventHealing = "healing"
+	// EventHealed - the connection reported DOWN is healed with the same NSE
+	EventHealed = "healed"
+	// EventReselected - the connection reported DOWN is UP again with another NSE
+	EventReselected = "reselected"
+	// EventDown - the connection is reported DOWN by NSMgr
+	EventDown = "down"
+	// EventClosed - the connection is closed
+	EventClosed = "closed"
+)
+
+type webhookEvent struct {
+	name  string
+	state string
+}
+
+var webhookEvents = map[connection.EventType]webhookEvent{
+	connection.EventRequested:   {EventRequested, status.StateRequested},
+	connection.EventEstablished: {EventEstablished, status.StateUp},
+	connection.EventHealing:     {EventHealing, status.StateHealing},
+	connection.EventHealed:      {EventHealed, status.StateUp},
+	connection.EventReselected:  {EventReselected, status.StateUp},
+	connection.EventDown:        {EventDown, status.StateDown},
+	connection.EventClosed:      {EventClosed, status.StateClosed},
+}
+
+// Payload - JSON body of the webhook request
+type Payload struct {
+	Event      string             `json:"event"`
+	Time       time.Time          `json:"time"`
+	Client     string             `json:"client"`
+	Connection *status.Connection `json:"connection"`
+}
+
+// Notifier - delivers the connection state transitions to the webhook targets in the order of the events, each target
+// independently of the others
+type Notifier struct {
+	name        string
+	targets     []string
+	client      *http.Client
+	secret      []byte
+	maxAttempts int
+	backoff     backoff.Backoff
+	queueSize   int
+}
+
+// NewNotifier - creates a Notifier of the client with the name delivering the events to the targets URLs
+func NewNotifier(name string, targets []string, opts ...Option) *Notifier {
+	n := &Notifier{
+		name:        name,
+		targets:     targets,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		maxAttempts: 5,
+		backoff: backoff.Backoff{
+			InitialDelay: 500 * time.Millisecond,
+			MaxDelay:     30 * time.Second,
+			Multiplier:   2,
+			Jitter:       0.2,
+		},
+		queueSize: 100,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// delivery - marshaled Payload queued for a target
+type delivery struct {
+	event string
+	id    string
+	body  []byte
+}
+
+// Watch - starts delivering the broker events until ctx is done. The events received before are still delivered after
+// that, the returned channel is closed once they are.
+func (n *Notifier) Watch(ctx context.Context, broker *connection.EventBroker) <-chan struct{} {
+	logger := log.FromContext(ctx)
+	events := broker.Subscribe(ctx)
+
+	// The targets may be slow, so the events are queued per target to not be dropped by the broker and to not wait
+	// for the other targets
+	queues := make([]chan *delivery, len(n.targets))
+	for i := range queues {
+		queues[i] = make(chan *delivery, n.queueSize)
+	}
+	go func() {
+		defer func() {
+			for _, queue := range queues {
+				close(queue)
+			}
+		}()
+		for event := range events {
+			e, ok := webhookEvents[event.Type]
+			if !ok {
+				continue
+			}
+			d, err := n.newDelivery(event, e)
+			if err != nil {
+				logger.Errorf("failed to marshal webhook payload: %v", err.Error())
+				continue
+			}
+			for i, queue := range queues {
+				select {
+				case queue <- d:
+				default:
+					logger.Warnf("webhook queue of %s is full, %s event for %s is dropped", n.targets[i], d.event, d.id)
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	// The attempts are limited by maxAttempts, so the queues are delivered after ctx is done
+	deliverCtx := context.WithoutCancel(ctx)
+	for i, queue := range queues {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range queue {
+				if err := n.send(deliverCtx, n.targets[i], d.event, d.body); err != nil {
+					logger.Errorf("failed to deliver %s event for %s to %s: %v", d.event, d.id, n.targets[i], err.Error())
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wg.Wait()
+	}()
+	return done
+}
+
+// newDelivery - returns the delivery of the event posted as e
+func (n *Notifier) newDelivery(event connection.Event, e webhookEvent) (*delivery, error) {
+	body, err := json.Marshal(&Payload{
+		Event:      e.name,
+		Time:       time.Now().UTC(),
+		Client:     n.name,
+		Connection: status.NewConnection(event, e.state),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &delivery{event: e.name, id: event.ID, body: body}, nil
+}
+
+// send - posts the body to the target, retrying the network errors and the 429 and 5xx responses
+func (n *Notifier) send(ctx context.Context, target, event string, body []byte) error {
+	retryBackoff := n.backoff
+	attempts := backoff.NewBudget(n.maxAttempts)
+	for {
+		if err := attempts.Take(); err != nil {
+			return errors.Wrap(err, "no delivery attempts left")
+		}
+		retry, err := n.post(ctx, target, event, body)
+		if err == nil || !retry {
+			return err
+		}
+		log.FromContext(ctx).Warnf("failed to deliver %s event to %s: %v", event, target, err.Error())
+		if waitErr := retryBackoff.Wait(ctx); waitErr != nil {
+			return errors.Wrap(err, "delivery cancelled")
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, target, event string, body []byte) (retry bool, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.Wrap(err, "invalid webhook request")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, event)
+	if len(n.secret) != 0 {
+		request.Header.Set(SignatureHeader, Sign(n.secret, body))
+	}
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return true, errors.Wrap(err, "webhook request failed")
+	}
+	_ = response.Body.Close()
+
+	switch {
+	case response.StatusCode < http.StatusMultipleChoices:
+		return false, nil
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError:
+		return true, errors.Errorf("webhook responded %s", response.Status)
+	default:
+		return false, errors.Errorf("webhook responded %s", response.Status)
+	}
+}
+
+// Sign - returns the SignatureHeader value of the body signed with the secret
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
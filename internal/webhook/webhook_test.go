@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/webhook"
+)
+
+func TestNotifier_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt fails to check the retry
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	broker := connection.NewEventBroker()
+	webhook.NewNotifier("nsc", []string{server.URL},
+		webhook.WithSecret("secret"),
+		webhook.WithBackoff(backoff.Backoff{InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 1}),
+	).Watch(ctx, broker)
+
+	broker.Publish(ctx, connection.Event{
+		Type:           connection.EventHealed,
+		ID:             "nsc-0",
+		NetworkService: "kernel://my-service/nsm-1",
+		Connection: &networkservice.Connection{
+			Id:                         "nsc-0",
+			NetworkService:             "my-service",
+			NetworkServiceEndpointName: "nse-1",
+		},
+	})
+
+	var r *http.Request
+	select {
+	case r = <-requests:
+	case <-time.After(time.Second):
+		require.FailNow(t, "webhook is not delivered")
+	}
+	body := <-bodies
+
+	require.Equal(t, int32(2), attempts.Load())
+	require.Equal(t, webhook.EventHealed, r.Header.Get(webhook.EventHeader))
+	require.Equal(t, webhook.Sign([]byte("secret"), body), r.Header.Get(webhook.SignatureHeader))
+
+	payload := new(webhook.Payload)
+	require.NoError(t, json.Unmarshal(body, payload))
+	require.Equal(t, webhook.EventHealed, payload.Event)
+	require.Equal(t, "nsc", payload.Client)
+	require.Equal(t, "nse-1", payload.Connection.NetworkServiceEndpointName)
+}
+
+func TestNotifier_Drain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events <- r.Header.Get(webhook.EventHeader)
+	}))
+	defer server.Close()
+
+	broker := connection.NewEventBroker()
+	done := webhook.NewNotifier("nsc", []string{server.URL}).Watch(ctx, broker)
+
+	// The closed event published right before the shutdown is still delivered
+	broker.Publish(ctx, connection.Event{
+		Type:           connection.EventClosed,
+		ID:             "nsc-0",
+		NetworkService: "kernel://my-service/nsm-1",
+	})
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "webhooks are not drained")
+	}
+	require.Len(t, events, 1)
+	require.Equal(t, webhook.EventClosed, <-events)
+}
+
+func TestNotifier_IndependentTargets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	events := make(chan string, 10)
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events <- r.Header.Get(webhook.EventHeader)
+	}))
+	defer fast.Close()
+
+	broker := connection.NewEventBroker()
+	webhook.NewNotifier("nsc", []string{slow.URL, fast.URL}, webhook.WithTimeout(time.Minute)).Watch(ctx, broker)
+
+	// The fast target receives the events while the slow one is still handling the first one
+	for _, eventType := range []connection.EventType{connection.EventDown, connection.EventHealing} {
+		broker.Publish(ctx, connection.Event{
+			Type:           eventType,
+			ID:             "nsc-0",
+			NetworkService: "kernel://my-service/nsm-1",
+			Connection:     &networkservice.Connection{Id: "nsc-0"},
+		})
+	}
+	for _, event := range []string{webhook.EventDown, webhook.EventHealing} {
+		select {
+		case got := <-events:
+			require.Equal(t, event, got)
+		case <-time.After(time.Second):
+			require.FailNow(t, "webhook is not delivered to the fast target")
+		}
+	}
+}
@@ -25,11 +25,13 @@ package main
 import (
 	"context"
 	"crypto/tls"
-	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -38,6 +40,7 @@ import (
 	"github.com/edwarnicke/grpcfd"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
@@ -64,30 +67,66 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/connectioncontext/dnscontext"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
-	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/cache"
 	dnschain "github.com/networkservicemesh/sdk/pkg/tools/dnsutils/chain"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/checkmsg"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/dnsconfigs"
-	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/fanout"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/noloop"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/searches"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
-	"github.com/networkservicemesh/sdk/pkg/tools/nsurl"
 	"github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
 	"github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 	"github.com/networkservicemesh/sdk/pkg/tools/tracing"
 
+	"github.com/networkservicemesh/cmd-nsc/internal/backoff"
 	"github.com/networkservicemesh/cmd-nsc/internal/config"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/control"
+	"github.com/networkservicemesh/cmd-nsc/internal/dnsdiag"
+	"github.com/networkservicemesh/cmd-nsc/internal/failover"
+	"github.com/networkservicemesh/cmd-nsc/internal/health"
+	"github.com/networkservicemesh/cmd-nsc/internal/hooks"
+	"github.com/networkservicemesh/cmd-nsc/internal/httputils"
+	"github.com/networkservicemesh/cmd-nsc/internal/localdns"
+	"github.com/networkservicemesh/cmd-nsc/internal/metrics"
+	"github.com/networkservicemesh/cmd-nsc/internal/peerauth"
+	"github.com/networkservicemesh/cmd-nsc/internal/perservice"
+	"github.com/networkservicemesh/cmd-nsc/internal/resolvconf"
+	"github.com/networkservicemesh/cmd-nsc/internal/state"
+	"github.com/networkservicemesh/cmd-nsc/internal/status"
+	"github.com/networkservicemesh/cmd-nsc/internal/svidfile"
+	"github.com/networkservicemesh/cmd-nsc/internal/webhook"
 )
 
+const (
+	// exitCodeOneShotFailed - exit code used when not all Network Services are connected in one-shot mode, it differs
+	// from 1 of log.Fatalf and 2 of panic
+	exitCodeOneShotFailed = 5
+	// exitCodeRetryBudgetExhausted - exit code used when the request retry budget is exhausted
+	exitCodeRetryBudgetExhausted = 3
+	// exitCodeHookFailed - exit code used when a hook fails with exit failure policy
+	exitCodeHookFailed = 4
+)
+
+// eventsDrainTimeout - maximum time the connection events received before the shutdown are handled
+const eventsDrainTimeout = 15 * time.Second
+
+// errHookFailed - shutdown cause of a hook failed with exit failure policy
+var errHookFailed = errors.New("hook failure policy is exit")
+
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// SIGHUP is handled from the start, so the one received during the setup reloads the configuration instead of
+	// terminating cmd-nsc
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	defer signal.Stop(reloadCh)
+
 	// ********************************************************************************
 	// Setup logger
 	// ********************************************************************************
@@ -101,40 +140,26 @@ func main() {
 	// ********************************************************************************
 	// Get config from environment
 	// ********************************************************************************
-	c := &config.Config{}
-	if err := envconfig.Usage("nsm", c); err != nil {
+	if err := envconfig.Usage("nsm", &config.Config{}); err != nil {
 		logger.Fatal(err)
 	}
-	if err := envconfig.Process("nsm", c); err != nil {
-		logger.Fatalf("error processing rootConf from env: %+v", err)
+	c, err := config.Load()
+	if err != nil {
+		logger.Fatalf("error processing rootConf: %+v", err)
 	}
-
-	level, err := logrus.ParseLevel(c.LogLevel)
+	services, err := c.Services()
 	if err != nil {
-		logrus.Fatalf("invalid log level %s", c.LogLevel)
+		logger.Fatalf("error processing network services: %+v", err)
 	}
-	logrus.SetLevel(level)
-	logruslogger.SetupLevelChangeOnSignal(ctx, map[os.Signal]logrus.Level{
-		syscall.SIGUSR1: logrus.TraceLevel,
-		syscall.SIGUSR2: level,
-	})
+
+	setupLogging(ctx, c)
 
 	logger.Infof("rootConf: %+v", c)
 
 	// ********************************************************************************
 	// Configure Open Telemetry
 	// ********************************************************************************
-	if opentelemetry.IsEnabled() {
-		collectorAddress := c.OpenTelemetryEndpoint
-		spanExporter := opentelemetry.InitSpanExporter(ctx, collectorAddress)
-		metricExporter := opentelemetry.InitOPTLMetricExporter(ctx, collectorAddress, c.MetricsExportInterval)
-		o := opentelemetry.Init(ctx, spanExporter, metricExporter, c.Name)
-		defer func() {
-			if err = o.Close(); err != nil {
-				logger.Error(err.Error())
-			}
-		}()
-	}
+	defer setupOpenTelemetry(ctx, c)()
 
 	// ********************************************************************************
 	// Configure pprof
@@ -144,83 +169,50 @@ func main() {
 	}
 
 	// ********************************************************************************
-	// Get a x509Source
+	// Configure Prometheus metrics
 	// ********************************************************************************
-	source, err := workloadapi.NewX509Source(ctx)
-	if err != nil {
-		logger.Fatalf("error getting x509 source: %v", err.Error())
+	nscMetrics := metrics.New()
+	if c.PrometheusEnabled {
+		go serveMetrics(ctx, c.PrometheusListenOn, nscMetrics)
 	}
-	var svid *x509svid.SVID
-	svid, err = source.GetX509SVID()
-	if err != nil {
-		logger.Fatalf("error getting x509 svid: %v", err.Error())
-	}
-	logger.Infof("sVID: %q", svid.ID)
 
-	tlsClientConfig := tlsconfig.MTLSClientConfig(source, source, tlsconfig.AuthorizeAny())
-	tlsClientConfig.MinVersion = tls.VersionTLS12
+	// ********************************************************************************
+	// Configure local DNS server
+	// ********************************************************************************
+	dnsConfigsMap := new(genericsync.Map[string, []*networkservice.DNSConfig])
+	dnsDiagnostics := dnsdiag.New(dnsConfigsMap)
+	if c.DebugEnabled {
+		go serveDebug(ctx, c.DebugListenOn, dnsDiagnostics)
+	}
+	resolvConf := startLocalDNS(ctx, c, dnsConfigsMap, nscMetrics, dnsDiagnostics)
+	defer restoreResolvConf(ctx, resolvConf)
+	// Fatalf exits without running the deferred calls, so resolv.conf is restored before
+	fatalf := func(format string, args ...interface{}) {
+		restoreResolvConf(ctx, resolvConf)
+		logger.Fatalf(format, args...)
+	}
 
 	// ********************************************************************************
 	// Create Network Service Manager nsmClient
 	// ********************************************************************************
-	dialOptions := append(tracing.WithTracingDial(),
-		grpcfd.WithChainStreamInterceptor(),
-		grpcfd.WithChainUnaryInterceptor(),
-		grpc.WithDefaultCallOptions(
-			grpc.WaitForReady(true),
-			grpc.PerRPCCredentials(token.NewPerRPCCredentials(spiffejwt.TokenGeneratorFunc(source, c.MaxTokenLifetime))),
-		),
-		grpc.WithTransportCredentials(
-			grpcfd.TransportCredentials(
-				credentials.NewTLS(tlsClientConfig),
-			),
-		),
-	)
-
-	dnsClient := null.NewClient()
-	if c.LocalDNSServerEnabled {
-		dnsConfigsMap := new(genericsync.Map[string, []*networkservice.DNSConfig])
-		dnsClient = dnscontext.NewClient(dnscontext.WithChainContext(ctx), dnscontext.WithDNSConfigsMap(dnsConfigsMap))
-
-		dnsServerHandler := dnschain.NewDNSHandler(
-			checkmsg.NewDNSHandler(),
-			dnsconfigs.NewDNSHandler(dnsConfigsMap),
-			searches.NewDNSHandler(),
-			noloop.NewDNSHandler(),
-			cache.NewDNSHandler(),
-			fanout.NewDNSHandler(),
-		)
-
-		go dnsutils.ListenAndServe(ctx, dnsServerHandler, c.LocalDNSServerAddress)
+	dialOptions, err := newDialOptions(ctx, c)
+	if err != nil {
+		fatalf("failed to configure NSMgr connection: %v", err.Error())
 	}
 
-	var healOptions = []heal.Option{heal.WithLivenessCheckInterval(c.LivenessCheckInterval),
-		heal.WithLivenessCheckTimeout(c.LivenessCheckTimeout)}
+	// The next NSMgr is used if the current one is unreachable
+	connectTo, failoverOptions := failover.Target(c.ConnectTo)
+	dialOptions = append(dialOptions, failoverOptions...)
 
-	if c.LivenessCheckEnabled {
-		healOptions = append(healOptions, heal.WithLivenessCheck(kernelheal.KernelLivenessCheck))
+	// DNS and heal clients are chosen per Network Service
+	dnsContextClient := dnscontext.NewClient(dnscontext.WithChainContext(ctx), dnscontext.WithDNSConfigsMap(dnsConfigsMap))
+	dnsClient := perservice.NewClient(null.NewClient())
+	if c.LocalDNSServerEnabled {
+		dnsClient = perservice.NewClient(dnsContextClient)
 	}
+	healClient := perservice.NewClient(heal.NewClient(ctx, healOptions(c.LivenessCheckEnabled, c.LivenessCheckInterval, c.LivenessCheckTimeout)...))
 
-	nsmClient := client.NewClient(ctx,
-		client.WithClientURL(&c.ConnectTo),
-		client.WithName(c.Name),
-		client.WithAuthorizeClient(authorize.NewClient()),
-		client.WithHealClient(heal.NewClient(ctx, healOptions...)),
-		client.WithAdditionalFunctionality(
-			clientinfo.NewClient(),
-			upstreamrefresh.NewClient(ctx),
-			sriovtoken.NewClient(),
-			mechanisms.NewClient(map[string]networkservice.NetworkServiceClient{
-				vfiomech.MECHANISM:   chain.NewNetworkServiceClient(vfio.NewClient()),
-				kernelmech.MECHANISM: chain.NewNetworkServiceClient(kernel.NewClient()),
-			}),
-			sendfd.NewClient(),
-			dnsClient,
-			excludedprefixes.NewClient(excludedprefixes.WithAwarenessGroups(c.AwarenessGroups)),
-		),
-		client.WithDialTimeout(c.DialTimeout),
-		client.WithDialOptions(dialOptions...),
-	)
+	nsmClient := newNSMClient(ctx, c, connectTo, dialOptions, dnsClient, healClient)
 
 	// ********************************************************************************
 	// Configure signal handling context
@@ -229,11 +221,13 @@ func main() {
 		ctx,
 		os.Interrupt,
 		// More Linux signals here
-		syscall.SIGHUP,
 		syscall.SIGTERM,
 		syscall.SIGQUIT,
 	)
 	defer cancelSignalCtx()
+	// A hook failed with exit failure policy shuts cmd-nsc down the same way as the signals
+	signalCtx, shutdown := context.WithCancelCause(signalCtx)
+	defer shutdown(nil)
 
 	// ********************************************************************************
 	// Create Network Service Manager monitorClient
@@ -241,10 +235,10 @@ func main() {
 	dialCtx, cancelDial := context.WithTimeout(signalCtx, c.DialTimeout)
 	defer cancelDial()
 
-	logger.Infof("NSC: Connecting to Network Service Manager %v", c.ConnectTo.String())
-	cc, err := grpc.DialContext(dialCtx, grpcutils.URLToTarget(&c.ConnectTo), dialOptions...)
+	logger.Infof("NSC: Connecting to Network Service Manager %v", c.ConnectToString())
+	cc, err := grpc.DialContext(dialCtx, grpcutils.URLToTarget(connectTo), dialOptions...)
 	if err != nil {
-		logger.Fatalf("failed dial to NSMgr: %v", err.Error())
+		fatalf("failed dial to NSMgr: %v", err.Error())
 	}
 
 	monitorClient := networkservice.NewMonitorConnectionClient(cc)
@@ -252,122 +246,516 @@ func main() {
 	// ********************************************************************************
 	// Initiate connections
 	// ********************************************************************************
-	for i := 0; i < len(c.NetworkServices); i++ {
-		// Update network services configs
-		id := fmt.Sprintf("%s-%d", c.Name, i)
+	broker := connection.NewEventBroker()
+	// The consumers outlive pool.Stop to handle the close events
+	eventsCtx, cancelEvents := context.WithCancel(ctx)
+	eventsDone := watchEvents(eventsCtx, c, broker, nscMetrics, shutdown)
+	resolvConf.Watch(signalCtx, broker)
+	dnsDiagnostics.Watch(signalCtx, broker)
+	managerClient := chain.NewNetworkServiceClient(metrics.NewClient(nscMetrics), nsmClient)
+
+	managerOptions, err := newManagerOptions(c, monitorClient, broker)
+	if err != nil {
+		fatalf("failed to configure connections: %v", err.Error())
+	}
 
-		monitorCtx, cancelMonitor := context.WithTimeout(signalCtx, c.RequestTimeout)
-		monitoredConnections, err := startMonitoring(monitorCtx, monitorClient, id)
-		if err != nil {
-			logger.Errorf("failed connect to monitor connections: %v", err.Error())
+	pool := newPool(ctx, c, managerClient, managerOptions, dnsContextClient, dnsClient, healClient)
+	stopEvents := func() {
+		cancelEvents()
+		drainEvents(ctx, eventsDone)
+	}
+	stopPool := func() {
+		pool.Stop(ctx)
+		stopEvents()
+	}
+
+	if c.ControlSocket != "" {
+		if err := serveControlAPI(signalCtx, c, pool, broker); err != nil {
+			fatalf("failed to serve control API: %v", err.Error())
 		}
+	}
+	if c.HealthEnabled {
+		go httputils.ListenAndServe(signalCtx, c.HealthListenOn, health.NewHandler(pool, cc, c.ReadinessNetworkServices...))
+	}
 
-		for {
-			requestCtx, requestCancel := context.WithTimeout(ctx, c.RequestTimeout)
-			defer requestCancel()
+	// ********************************************************************************
+	// Request network services and reload them on SIGHUP or config file change
+	// ********************************************************************************
+	err = <-watchReloads(signalCtx, c, pool, services, reloadCh)
+	if c.OneShot {
+		code := oneShot(ctx, pool, stopEvents)
+		restoreResolvConf(ctx, resolvConf)
+		os.Exit(code)
+	}
+	if errors.Is(err, backoff.ErrBudgetExhausted) {
+		stopPool()
+		restoreResolvConf(ctx, resolvConf)
+		logger.Errorf("request retry budget exhausted, exiting with code %d", exitCodeRetryBudgetExhausted)
+		os.Exit(exitCodeRetryBudgetExhausted)
+	}
 
-			// Construct a request
-			request := constructRequest(ctx, c, id, &c.NetworkServices[i], monitoredConnections)
+	<-signalCtx.Done()
+	stopPool()
+	if err := context.Cause(signalCtx); errors.Is(err, errHookFailed) {
+		restoreResolvConf(ctx, resolvConf)
+		logger.Errorf("%v, exiting with code %d", err.Error(), exitCodeHookFailed)
+		os.Exit(exitCodeHookFailed)
+	}
+}
 
-			resp, err := nsmClient.Request(requestCtx, request)
-			if err != nil {
-				logger.Errorf("failed connect to NSMgr: %v", err.Error())
-				continue
-			}
+// newPool - returns the pool of the Network Services connections requested with client, the DNS and heal clients are
+// stored per connection according to the Network Service settings
+func newPool(ctx context.Context, c *config.Config, client networkservice.NetworkServiceClient, managerOptions []connection.Option,
+	dnsContextClient networkservice.NetworkServiceClient, dnsClient, healClient *perservice.Client) *connection.Pool {
+	return connection.NewPool(c.Name, func(id string, service *config.NetworkService) *connection.Manager {
+		healClient.Store(id, heal.NewClient(ctx, healOptions(service.LivenessCheckEnabled, service.LivenessCheckInterval, service.LivenessCheckTimeout)...))
+		if service.LocalDNSServerEnabled {
+			dnsClient.Store(id, dnsContextClient)
+		} else {
+			dnsClient.Store(id, null.NewClient())
+		}
 
-			defer func() {
-				closeCtx, cancelClose := context.WithTimeout(ctx, c.RequestTimeout)
-				defer cancelClose()
-				_, _ = nsmClient.Close(closeCtx, resp)
-			}()
+		serviceOptions := []connection.Option{
+			connection.WithRequestTimeout(service.RequestTimeout),
+			connection.WithLabels(service.Labels),
+		}
+		if service.LivenessCheckEnabled {
+			serviceOptions = append(serviceOptions, connection.WithLivenessCheck(kernelheal.KernelLivenessCheck, service.LivenessCheckTimeout))
+		}
+		return connection.NewManager(client, id, service.URL, append(serviceOptions, managerOptions...)...)
+	}, c.RequestConcurrency, connection.WithMaxTotalAttempts(c.RequestMaxTotalAttempts), connection.WithRelease(func(id string) {
+		healClient.Delete(id)
+		dnsClient.Delete(id)
+	}))
+}
 
-			logger.Infof("successfully connected to %v. Response: %v", resp.NetworkService, resp)
-			break
+// oneShot - returns the exit code of one-shot mode, the connections are left open if all of them are established, so a
+// next cmd-nsc instance can take them over, otherwise they are closed. In both cases the events are drained with
+// stopEvents before returning. The exhausted retry budget also exits with exitCodeOneShotFailed.
+func oneShot(ctx context.Context, pool *connection.Pool, stopEvents func()) int {
+	defer stopEvents()
+	for _, m := range pool.Managers() {
+		if m.Connection() == nil {
+			pool.Stop(ctx)
+			log.FromContext(ctx).Errorf("failed connect to %v, exiting with code %d", m.NetworkService().String(), exitCodeOneShotFailed)
+			return exitCodeOneShotFailed
 		}
-		cancelMonitor()
 	}
+	log.FromContext(ctx).Info("all Network Services are connected, exiting without closing the connections")
+	return 0
+}
 
-	// Wait for cancel event to terminate
-	<-signalCtx.Done()
+// setupOpenTelemetry - initializes Open Telemetry if it is enabled, returns the function closing it
+func setupOpenTelemetry(ctx context.Context, c *config.Config) func() {
+	if !opentelemetry.IsEnabled() {
+		return func() {}
+	}
+	collectorAddress := c.OpenTelemetryEndpoint
+	spanExporter := opentelemetry.InitSpanExporter(ctx, collectorAddress)
+	metricExporter := opentelemetry.InitOPTLMetricExporter(ctx, collectorAddress, c.MetricsExportInterval)
+	o := opentelemetry.Init(ctx, spanExporter, metricExporter, c.Name)
+	return func() {
+		if err := o.Close(); err != nil {
+			log.FromContext(ctx).Error(err.Error())
+		}
+	}
 }
 
-func startMonitoring(ctx context.Context, monitorClient networkservice.MonitorConnectionClient, id string) (*genericsync.Map[string, *networkservice.Connection], error) {
-	var monitoredConnections genericsync.Map[string, *networkservice.Connection]
-	stream, err := monitorClient.MonitorConnections(ctx, &networkservice.MonitorScopeSelector{
-		PathSegments: []*networkservice.PathSegment{
-			{
-				Id: id,
-			},
-		},
-	})
+// startLocalDNS - starts the local DNS server if it is required, returns the resolv.conf manager if resolv.conf is
+// managed and nil otherwise. The original nameservers of the managed resolv.conf are queried only if the DNS servers
+// of the connections fail.
+func startLocalDNS(ctx context.Context, c *config.Config, dnsConfigsMap *genericsync.Map[string, []*networkservice.DNSConfig], m *metrics.Metrics, d *dnsdiag.Diagnostics) *resolvconf.Manager {
+	if !c.LocalDNSServerRequired() {
+		return nil
+	}
+	// The handlers are created before resolv.conf is managed, so the invalid configuration leaves it untouched
+	handlers := newLocalDNSHandlers(ctx, c, dnsConfigsMap, m, d)
+	resolvConf := manageResolvConf(ctx, c, dnsConfigsMap)
+	fallback, err := localdns.NewFallbackHandler(resolvConf.Fallback()...)
 	if err != nil {
-		return &monitoredConnections, errors.Wrap(err, "error from monitorConnectionClient")
+		restoreResolvConf(ctx, resolvConf)
+		log.FromContext(ctx).Fatalf("invalid resolv.conf nameservers: %v", err.Error())
 	}
+	handlers = append(handlers,
+		metrics.NewUpstreamDNSHandler(m),
+		fallback,
+		localdns.NewUpstreamHandler(c.LocalDNSUpstreamMode, c.LocalDNSUpstreamTimeout, c.LocalDNSBufferSize),
+	)
+
+	localdns.ListenAndServe(ctx, dnschain.NewDNSHandler(handlers...), c.LocalDNSServerAddress)
+	return resolvConf
+}
 
-	// Recv initial event
-	event, err := stream.Recv()
+// newLocalDNSHandlers - returns the local DNS server handlers configured by c up to the upstream ones
+func newLocalDNSHandlers(ctx context.Context, c *config.Config, dnsConfigsMap *genericsync.Map[string, []*networkservice.DNSConfig], m *metrics.Metrics, d *dnsdiag.Diagnostics) []dnsutils.Handler {
+	var handlers []dnsutils.Handler
+	if c.LocalDNSQueryLogEnabled {
+		handlers = append(handlers, dnsdiag.NewQueryLogHandler(d, c.LocalDNSQueryLogSampleRate, c.LocalDNSQueryLogRateLimit))
+	}
+	handlers = append(handlers,
+		checkmsg.NewDNSHandler(),
+		dnsconfigs.NewDNSHandler(dnsConfigsMap),
+		searches.NewDNSHandler(),
+		// Each search domain query is counted, the same as by the cache and upstream metrics handlers below
+		metrics.NewDNSHandler(m),
+		noloop.NewDNSHandler(),
+		localdns.NewBlockHandler(c.LocalDNSBlockedSuffixes...),
+	)
+	if c.LocalDNSHostsFile != "" {
+		hosts, err := localdns.NewHostsHandler(c.LocalDNSHostsFile)
+		if err != nil {
+			log.FromContext(ctx).Fatalf("failed to load local DNS hosts file: %v", err.Error())
+		}
+		handlers = append(handlers, hosts)
+	}
+	rewrite, err := localdns.NewRewriteHandler(c.LocalDNSRewrites...)
 	if err != nil {
-		return &monitoredConnections, errors.Wrap(err, "error from monitorConnection stream")
+		log.FromContext(ctx).Fatalf("invalid local DNS rewrites: %v", err.Error())
 	}
-	for k, conn := range event.Connections {
-		monitoredConnections.Store(k, conn)
+	handlers = append(handlers, rewrite)
+	if c.LocalDNSCacheEnabled {
+		handlers = append(handlers, metrics.NewCacheDNSHandler(m), localdns.NewCacheHandler(c.LocalDNSCacheSize, c.LocalDNSCacheMaxTTL))
+	}
+	return handlers
+}
+
+// manageResolvConf - points resolv.conf at the local DNS server if it is managed, returns nil otherwise
+func manageResolvConf(ctx context.Context, c *config.Config, dnsConfigsMap *genericsync.Map[string, []*networkservice.DNSConfig]) *resolvconf.Manager {
+	if !c.ResolvConfManaged {
+		return nil
 	}
+	localIP, _, err := net.SplitHostPort(c.LocalDNSServerAddress)
+	if err != nil {
+		log.FromContext(ctx).Fatalf("invalid local DNS server address %s: %v", c.LocalDNSServerAddress, err.Error())
+	}
+	resolvConf := resolvconf.NewManager(c.ResolvConfPath, localIP, dnsConfigsMap)
+	if err := resolvConf.Apply(); err != nil {
+		// The backup may be already written
+		restoreResolvConf(ctx, resolvConf)
+		log.FromContext(ctx).Fatalf("failed to manage resolv.conf: %v", err.Error())
+	}
+	return resolvConf
+}
+
+// restoreResolvConf - restores the original resolv.conf if it is managed
+func restoreResolvConf(ctx context.Context, resolvConf *resolvconf.Manager) {
+	if err := resolvConf.Restore(); err != nil {
+		log.FromContext(ctx).Errorf("failed to restore resolv.conf: %v", err.Error())
+	}
+}
+
+// serveMetrics - serves Prometheus /metrics endpoint on address until ctx is done
+func serveMetrics(ctx context.Context, address string, m *metrics.Metrics) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	httputils.ListenAndServe(ctx, address, mux)
+}
+
+// serveDebug - serves /debug/dns endpoint dumping the DNS configs per connection on address until ctx is done
+func serveDebug(ctx context.Context, address string, d *dnsdiag.Diagnostics) {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/dns", d)
+	httputils.ListenAndServe(ctx, address, mux)
+}
+
+// serveControlAPI - starts the local control API on the unix socket
+func serveControlAPI(ctx context.Context, c *config.Config, pool *connection.Pool, broker *connection.EventBroker) error {
+	logger := log.FromContext(ctx)
+
+	controlServer := control.NewServer(c, pool, broker)
+	server := grpc.NewServer()
+	networkservice.RegisterNetworkServiceServer(server, controlServer)
+	networkservice.RegisterMonitorConnectionServer(server, controlServer)
+
+	if err := os.Remove(c.ControlSocket); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove stale control API socket %s", c.ControlSocket)
+	}
+	listenOn := &url.URL{Scheme: "unix", Path: c.ControlSocket}
+	srvErrCh := grpcutils.ListenAndServe(ctx, listenOn, server)
+	logger.Infof("Control API is listening on %v", listenOn.String())
 
-	// Start monitoring in the background
 	go func() {
+		if err, ok := <-srvErrCh; ok && err != nil {
+			logger.Errorf("control API server failed: %v", err.Error())
+		}
+	}()
+	return nil
+}
+
+// watchReloads - updates the pool with services and then with the reloaded configuration on SIGHUP received from
+// reloadCh or config file change until ctx is done. The updates run one at a time, a reload cancels the running update,
+// so the newest configuration wins. Returns the result of the first update not cancelled by a reload.
+func watchReloads(ctx context.Context, c *config.Config, pool *connection.Pool, services []*config.NetworkService, reloadCh <-chan os.Signal) <-chan error {
+	logger := log.FromContext(ctx)
+
+	var configChanges <-chan struct{}
+	if c.ConfigFile != "" && c.ConfigFileWatchInterval > 0 {
+		configChanges = config.WatchFile(ctx, c.ConfigFile, c.ConfigFileWatchInterval)
+	}
+
+	initialized := make(chan error, 1)
+	go func() {
+		initial := true
+		updated, cancelUpdate := startUpdate(ctx, pool, services)
 		for {
-			event, err := stream.Recv()
+			select {
+			case <-ctx.Done():
+				cancelUpdate()
+				if updated != nil && initial {
+					initialized <- <-updated
+				}
+				return
+			case err := <-updated:
+				updated = nil
+				if initial {
+					initial = false
+					initialized <- err
+				} else if err != nil {
+					logger.Errorf("failed to update network services: %v", err.Error())
+				}
+				continue
+			case <-reloadCh:
+				logger.Info("SIGHUP received, reloading configuration")
+			case <-configChanges:
+				logger.Infof("config file %s changed, reloading configuration", c.ConfigFile)
+			}
+
+			reloaded, err := loadNetworkServices()
 			if err != nil {
-				break
+				logger.Errorf("failed to reload configuration, keeping the current one: %+v", err)
+				continue
 			}
-			for k, conn := range event.Connections {
-				if event.GetType() == networkservice.ConnectionEventType_DELETE {
-					conn.State = networkservice.State_DOWN
-				}
-				monitoredConnections.Store(k, conn)
+			cancelUpdate()
+			if updated != nil {
+				<-updated
 			}
+			updated, cancelUpdate = startUpdate(ctx, pool, reloaded)
 		}
 	}()
+	return initialized
+}
+
+// startUpdate - starts updating the pool with services, returns the channel receiving the result and the function
+// cancelling the update
+func startUpdate(ctx context.Context, pool *connection.Pool, services []*config.NetworkService) (<-chan error, context.CancelFunc) {
+	updateCtx, cancelUpdate := context.WithCancel(ctx)
+	updated := make(chan error, 1)
+	go func() {
+		updated <- pool.Update(updateCtx, services)
+	}()
+	return updated, cancelUpdate
+}
 
-	return &monitoredConnections, nil
+// loadNetworkServices - reloads the configuration and returns its Network Services
+func loadNetworkServices() ([]*config.NetworkService, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return c.Services()
 }
 
-func constructRequest(ctx context.Context, c *config.Config, connectionID string, networkService *url.URL, monitoredConnections *genericsync.Map[string, *networkservice.Connection]) *networkservice.NetworkServiceRequest {
-	u := (*nsurl.NSURL)(networkService)
-
-	request := &networkservice.NetworkServiceRequest{
-		Connection: &networkservice.Connection{
-			Id:             connectionID,
-			NetworkService: u.NetworkService(),
-			Labels:         u.Labels(),
-		},
-		MechanismPreferences: []*networkservice.Mechanism{
-			u.Mechanism(),
-		},
-	}
-
-	// Looking for a match in the connections received from monitoring
-	monitoredConnections.Range(func(key string, conn *networkservice.Connection) bool {
-		path := conn.GetPath()
-		if path.Index == 1 && path.PathSegments[0].Id == connectionID && conn.Mechanism.Type == u.Mechanism().Type {
-			request.Connection = conn.Clone()
-			request.Connection.Path.Index = 0
-			request.Connection.Id = connectionID
-			return false
+// watchEvents - starts the consumers of the connection lifecycle events until ctx is done, the returned channel is
+// closed once they handle the events received before. A hook failed with exit failure policy calls shutdown.
+func watchEvents(ctx context.Context, c *config.Config, broker *connection.EventBroker, m *metrics.Metrics, shutdown context.CancelCauseFunc) <-chan struct{} {
+	var consumers []<-chan struct{}
+	m.Watch(ctx, broker)
+	if c.StatusFile != "" {
+		var opts []status.Option
+		if c.OneShot {
+			// The connections left on the exit are open
+			opts = append(opts, status.WithKeepOnShutdown())
+		}
+		consumers = append(consumers, status.NewWriter(c.StatusFile, opts...).Watch(ctx, broker))
+	}
+	if len(c.Hooks) != 0 || c.HooksDir != "" {
+		runner := hooks.NewRunner(c.Hooks, c.HooksDir, c.HooksTimeout, onHookFailure(ctx, c.HooksFailurePolicy, shutdown))
+		consumers = append(consumers, runner.Watch(ctx, broker))
+	}
+	if len(c.Webhooks) != 0 {
+		notifier := webhook.NewNotifier(c.Name, c.Webhooks,
+			webhook.WithSecret(c.WebhookSecret),
+			webhook.WithTimeout(c.WebhookTimeout),
+			webhook.WithMaxAttempts(c.WebhookMaxAttempts),
+			webhook.WithQueueSize(c.WebhookQueueSize),
+		)
+		consumers = append(consumers, notifier.Watch(ctx, broker))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, consumer := range consumers {
+			<-consumer
 		}
-		return true
+	}()
+	return done
+}
+
+// x509Source - source of the SVID and the trust bundle
+type x509Source interface {
+	x509svid.Source
+	x509bundle.Source
+}
+
+// newX509Source - returns the SVID files source if they are configured and the SPIFFE Workload API source otherwise
+func newX509Source(ctx context.Context, c *config.Config) (x509Source, error) {
+	if c.SVIDCertFile != "" {
+		return svidfile.NewSource(ctx, c.SVIDCertFile, c.SVIDKeyFile, c.SVIDBundleFile, c.SVIDFileWatchInterval)
+	}
+	source, err := workloadapi.NewX509Source(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to SPIFFE Workload API")
+	}
+	return source, nil
+}
+
+// newDialOptions - returns the NSMgr dial options authenticated with the SVID and the JWT tokens
+func newDialOptions(ctx context.Context, c *config.Config) ([]grpc.DialOption, error) {
+	source, err := newX509Source(ctx, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting x509 source")
+	}
+	svid, err := source.GetX509SVID()
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting x509 svid")
+	}
+	log.FromContext(ctx).Infof("sVID: %q", svid.ID)
+
+	tlsClientConfig, err := newTLSClientConfig(source, source, c.AllowedServers)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating TLS config")
+	}
+
+	return append(tracing.WithTracingDial(),
+		grpcfd.WithChainStreamInterceptor(),
+		grpcfd.WithChainUnaryInterceptor(),
+		grpc.WithDefaultCallOptions(
+			grpc.WaitForReady(true),
+			grpc.PerRPCCredentials(token.NewPerRPCCredentials(spiffejwt.TokenGeneratorFunc(source, c.MaxTokenLifetime))),
+		),
+		grpc.WithTransportCredentials(
+			grpcfd.TransportCredentials(
+				credentials.NewTLS(tlsClientConfig),
+			),
+		),
+	), nil
+}
+
+// newTLSClientConfig - returns the mTLS config accepting the NSMgr SPIFFE IDs matching allowedServers
+func newTLSClientConfig(svidSource x509svid.Source, bundleSource x509bundle.Source, allowedServers []string) (*tls.Config, error) {
+	authorizer, err := peerauth.NewAuthorizer(allowedServers)
+	if err != nil {
+		return nil, err
+	}
+	tlsClientConfig := tlsconfig.MTLSClientConfig(svidSource, bundleSource, authorizer)
+	tlsClientConfig.MinVersion = tls.VersionTLS12
+	return tlsClientConfig, nil
+}
+
+// onHookFailure - returns the hook failure handler of the failure policy, with exit policy it shuts cmd-nsc down
+func onHookFailure(ctx context.Context, policy string, shutdown context.CancelCauseFunc) func(err error) {
+	return func(err error) {
+		log.FromContext(ctx).Errorf("hook failed: %v", err.Error())
+		if policy == config.HooksFailurePolicyExit {
+			shutdown(errors.Wrap(errHookFailed, err.Error()))
+		}
+	}
+}
+
+// drainEvents - waits for the event consumers to handle the events received before the shutdown, but not longer than
+// eventsDrainTimeout
+func drainEvents(ctx context.Context, eventsDone <-chan struct{}) {
+	select {
+	case <-eventsDone:
+	case <-time.After(eventsDrainTimeout):
+		log.FromContext(ctx).Warnf("connection events are not handled in %v, exiting", eventsDrainTimeout)
+	}
+}
+
+// newNSMClient - returns the NSMgr client with the per Network Service DNS and heal clients
+func newNSMClient(ctx context.Context, c *config.Config, connectTo *url.URL, dialOptions []grpc.DialOption,
+	dnsClient, healClient networkservice.NetworkServiceClient) networkservice.NetworkServiceClient {
+	return client.NewClient(ctx,
+		client.WithClientURL(connectTo),
+		client.WithName(c.Name),
+		client.WithAuthorizeClient(authorize.NewClient()),
+		client.WithHealClient(healClient),
+		client.WithAdditionalFunctionality(
+			clientinfo.NewClient(),
+			upstreamrefresh.NewClient(ctx),
+			sriovtoken.NewClient(),
+			mechanisms.NewClient(map[string]networkservice.NetworkServiceClient{
+				vfiomech.MECHANISM:   chain.NewNetworkServiceClient(vfio.NewClient()),
+				kernelmech.MECHANISM: chain.NewNetworkServiceClient(kernel.NewClient()),
+			}),
+			sendfd.NewClient(),
+			dnsClient,
+			excludedprefixes.NewClient(excludedprefixes.WithAwarenessGroups(c.AwarenessGroups)),
+		),
+		client.WithDialTimeout(c.DialTimeout),
+		client.WithDialOptions(dialOptions...),
+	)
+}
+
+// setupLogging - applies the log level and format, SIGUSR1 switches the level to trace and SIGUSR2 back
+func setupLogging(ctx context.Context, c *config.Config) {
+	level, err := logrus.ParseLevel(c.LogLevel)
+	if err != nil {
+		logrus.Fatalf("invalid log level %s", c.LogLevel)
+	}
+	logrus.SetLevel(level)
+	logrus.SetFormatter(logFormatter(c.LogFormat))
+	logruslogger.SetupLevelChangeOnSignal(ctx, map[os.Signal]logrus.Level{
+		syscall.SIGUSR1: logrus.TraceLevel,
+		syscall.SIGUSR2: level,
 	})
+}
+
+// logFormatter - returns the logrus formatter of the format
+func logFormatter(format string) logrus.Formatter {
+	switch format {
+	case config.LogFormatJSON:
+		return &logrus.JSONFormatter{}
+	case config.LogFormatText:
+		return &logrus.TextFormatter{DisableColors: true, FullTimestamp: true}
+	default:
+		return &nested.Formatter{}
+	}
+}
+
+// newManagerOptions - returns the connection.Manager options shared by all Network Services
+func newManagerOptions(c *config.Config, monitorClient networkservice.MonitorConnectionClient, broker *connection.EventBroker) ([]connection.Option, error) {
+	opts := []connection.Option{
+		connection.WithMonitorClient(monitorClient),
+		connection.WithBackoff(backoff.Backoff{
+			InitialDelay: c.RequestBackoffInitialDelay,
+			MaxDelay:     c.RequestBackoffMaxDelay,
+			Multiplier:   c.RequestBackoffMultiplier,
+			Jitter:       c.RequestBackoffJitter,
+		}),
+		connection.WithMaxAttempts(c.RequestMaxAttempts),
+		connection.WithEventBroker(broker),
+	}
+	if c.StateFile != "" {
+		store, err := state.NewFile(c.StateFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, connection.WithStore(store))
+	}
+	return opts, nil
+}
 
-	lCheckCtx, lCheckCtxCancel := context.WithTimeout(ctx, c.LivenessCheckTimeout)
-	defer lCheckCtxCancel()
-	if request.GetConnection().State == networkservice.State_DOWN &&
-		(!c.LivenessCheckEnabled || !kernelheal.KernelLivenessCheck(lCheckCtx, request.GetConnection())) {
-		// We cannot Close this because the connection was not established through this chain.
-		// We can only reselect an endpoint
-		log.FromContext(ctx).Infof("NetworkServiceEndpoint %v is unavailable. Reconnection...", request.GetConnection().NetworkServiceEndpointName)
-		request.GetConnection().Mechanism = nil
-		request.GetConnection().NetworkServiceEndpointName = ""
-		request.GetConnection().State = networkservice.State_RESELECT_REQUESTED
-	}
-	return request
+// healOptions - returns the heal client options of the liveness check settings, the kernel liveness check is used if
+// it is enabled
+func healOptions(livenessCheckEnabled bool, livenessCheckInterval, livenessCheckTimeout time.Duration) []heal.Option {
+	options := []heal.Option{
+		heal.WithLivenessCheckInterval(livenessCheckInterval),
+		heal.WithLivenessCheckTimeout(livenessCheckTimeout),
+	}
+	if livenessCheckEnabled {
+		options = append(options, heal.WithLivenessCheck(kernelheal.KernelLivenessCheck))
+	}
+	return options
 }
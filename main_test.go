@@ -23,7 +23,9 @@ package main_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/stretchr/testify/require"
@@ -55,3 +57,170 @@ func TestParseUrlsFromEnv(t *testing.T) {
 	require.Equal(t, vfio.MECHANISM, url2.Mechanism().Type)
 	require.Equal(t, "intel/10G", url2.Labels()["sriovToken"])
 }
+
+func TestDefaultMechanismAndLabels(t *testing.T) {
+	t.Setenv("NSM_NETWORK_SERVICES", "vpn/if-vpn?app=vpn,vfio://second-service?sriovToken=intel/10G")
+	t.Setenv("NSM_MECHANISM", "kernel")
+	t.Setenv("NSM_LABELS", "app=nsc,zone=a")
+
+	c := &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.NoError(t, c.ApplyDefaultMechanism())
+
+	url1 := nsurl.NSURL(c.NetworkServices[0])
+	url2 := nsurl.NSURL(c.NetworkServices[1])
+
+	require.Equal(t, kernel.MECHANISM, url1.Mechanism().Type)
+	require.Equal(t, "vpn", url1.NetworkService())
+	require.Equal(t, "if-vpn", url1.Mechanism().GetParameters()[common.InterfaceNameKey])
+	require.Equal(t, "vpn", url1.Labels()["app"])
+
+	require.Equal(t, vfio.MECHANISM, url2.Mechanism().Type)
+
+	labels, err := c.LabelsMap()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app": "nsc", "zone": "a"}, labels)
+}
+
+func TestConfigIsValid(t *testing.T) {
+	t.Setenv("NSM_NETWORK_SERVICES", "kernel://vpn/nsm-interface-name,kernel://vpn2/nsm-1,kernel://vpn3/nsm-1,vfio://l2,tap://l3,kernel://vpn4/nsm:1")
+	t.Setenv("NSM_REQUEST_TIMEOUT", "0s")
+
+	c := &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.NoError(t, c.ApplyDefaultMechanism())
+
+	err := c.IsValid()
+	require.Error(t, err)
+	for _, problem := range []string{
+		"RequestTimeout should be positive",
+		`interface name "nsm-interface-name" is longer than 15 characters`,
+		`interface name "nsm-1" is already used by kernel://vpn2/nsm-1`,
+		"sriovToken label is required for VFIO mechanism",
+		`unsupported mechanism "tap"`,
+		`interface name "nsm:1" contains invalid character ':'`,
+	} {
+		require.Contains(t, err.Error(), problem)
+	}
+
+	t.Setenv("NSM_NETWORK_SERVICES", "")
+	t.Setenv("NSM_REQUEST_TIMEOUT", "15s")
+
+	c = &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.ErrorContains(t, c.IsValid(), "no network services are specified")
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+name: nsc-from-file
+requestTimeout: 30s
+labels:
+  app: "a,b=c"
+networkServices:
+  - kernel://my-service/nsm-1
+  - name: vpn
+    interface: if-vpn
+    labels:
+      comma: "x,y"
+    requestTimeout: 60s
+  - name: l2-controller
+    mechanism: vfio
+    labels:
+      sriovToken: l2.domain/1G
+`), 0o600))
+
+	t.Setenv("NSM_NETWORK_SERVICES", "")
+	require.NoError(t, os.Unsetenv("NSM_NETWORK_SERVICES"))
+	t.Setenv("NSM_REQUEST_TIMEOUT", "20s")
+
+	c := &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.NoError(t, c.LoadFile(configFile))
+	require.NoError(t, c.ApplyDefaultMechanism())
+	require.NoError(t, c.IsValid())
+
+	require.Equal(t, "nsc-from-file", c.Name)
+	require.Equal(t, 20*time.Second, c.RequestTimeout)
+	require.Equal(t, []string{"app=a,b=c"}, c.Labels)
+
+	services, err := c.Services()
+	require.NoError(t, err)
+	require.Len(t, services, 3)
+
+	require.Equal(t, "kernel://my-service/nsm-1", services[0].URL.String())
+	require.Equal(t, 20*time.Second, services[0].RequestTimeout)
+
+	url2 := (*nsurl.NSURL)(services[1].URL)
+	require.Equal(t, kernel.MECHANISM, url2.Mechanism().Type)
+	require.Equal(t, "if-vpn", url2.Mechanism().GetParameters()[common.InterfaceNameKey])
+	require.Equal(t, map[string]string{"comma": "x,y"}, url2.Labels())
+	require.Equal(t, time.Minute, services[1].RequestTimeout)
+
+	url3 := (*nsurl.NSURL)(services[2].URL)
+	require.Equal(t, vfio.MECHANISM, url3.Mechanism().Type)
+	require.Equal(t, "l2.domain/1G", url3.Labels()["sriovToken"])
+}
+
+func TestNetworkServiceOverrides(t *testing.T) {
+	t.Setenv("NSM_NETWORK_SERVICES", "kernel://vpn/nsm-1?nsc-request-timeout=1m&app=vpn,"+
+		"kernel://l2/nsm-2?nsc-liveness-check-interval=50ms&nsc-local-dns-server-enabled=false")
+	t.Setenv("NSM_LOCAL_DNS_SERVER_ENABLED", "true")
+
+	c := &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.NoError(t, c.IsValid())
+
+	services, err := c.Services()
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+
+	require.Equal(t, time.Minute, services[0].RequestTimeout)
+	require.Equal(t, c.LivenessCheckInterval, services[0].LivenessCheckInterval)
+	require.True(t, services[0].LocalDNSServerEnabled)
+	require.Equal(t, map[string]string{"app": "vpn"}, (*nsurl.NSURL)(services[0].URL).Labels())
+
+	require.Equal(t, c.RequestTimeout, services[1].RequestTimeout)
+	require.Equal(t, 50*time.Millisecond, services[1].LivenessCheckInterval)
+	require.False(t, services[1].LocalDNSServerEnabled)
+	require.Empty(t, (*nsurl.NSURL)(services[1].URL).Labels())
+
+	t.Setenv("NSM_NETWORK_SERVICES", "kernel://vpn/nsm-1?nsc-request-timeout=fast,kernel://l2/nsm-2?nsc-unknown=1")
+	c = &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	err = c.IsValid()
+	require.ErrorContains(t, err, "invalid nsc-request-timeout=fast")
+	require.ErrorContains(t, err, "invalid nsc-unknown=1")
+}
+
+func TestConnectToList(t *testing.T) {
+	t.Setenv("NSM_NETWORK_SERVICES", "kernel://my-service/nsm-1")
+	t.Setenv("NSM_CONNECT_TO", "unix:///var/lib/networkservicemesh/nsm.io.sock,tcp://10.0.0.1:5001")
+
+	c := &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.NoError(t, c.IsValid())
+	require.Len(t, c.ConnectTo, 2)
+	require.Equal(t, "tcp://10.0.0.1:5001", c.ConnectTo[1].String())
+
+	t.Setenv("NSM_CONNECT_TO", "localhost:5001")
+	c = &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.Error(t, c.IsValid())
+}
+
+func TestSRIOVTokenFromClientLabels(t *testing.T) {
+	t.Setenv("NSM_NETWORK_SERVICES", "vfio://l2")
+	t.Setenv("NSM_LABELS", "sriovToken=intel/10G")
+
+	c := &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.NoError(t, c.IsValid())
+
+	// The empty URL label overrides the client one
+	t.Setenv("NSM_NETWORK_SERVICES", "vfio://l2?sriovToken=")
+	c = &config.Config{}
+	require.NoError(t, envconfig.Process("nsm", c))
+	require.ErrorContains(t, c.IsValid(), "sriovToken label is required for VFIO mechanism")
+}
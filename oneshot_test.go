@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/cmd-nsc/internal/config"
+	"github.com/networkservicemesh/cmd-nsc/internal/connection"
+	"github.com/networkservicemesh/cmd-nsc/internal/metrics"
+)
+
+type connectClient struct{}
+
+func (c *connectClient) Request(_ context.Context, request *networkservice.NetworkServiceRequest, _ ...grpc.CallOption) (*networkservice.Connection, error) {
+	return request.GetConnection(), nil
+}
+
+func (c *connectClient) Close(context.Context, *networkservice.Connection, ...grpc.CallOption) (*emptypb.Empty, error) {
+	return new(emptypb.Empty), nil
+}
+
+func TestOneShot_DrainsEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "hook.out")
+	hook := filepath.Join(dir, "hook.sh")
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\nsleep 0.2\necho \"$NSC_HOOK_EVENT\" >> "+out+"\n"), 0o700))
+
+	c := &config.Config{
+		OneShot:      true,
+		Hooks:        []string{hook},
+		HooksTimeout: time.Second,
+		StatusFile:   filepath.Join(dir, "status.json"),
+	}
+	broker := connection.NewEventBroker()
+	eventsCtx, cancelEvents := context.WithCancel(ctx)
+	eventsDone := watchEvents(eventsCtx, c, broker, metrics.New(), func(error) {})
+
+	u, err := url.Parse("kernel://my-service/nsm-1")
+	require.NoError(t, err)
+	pool := connection.NewPool("nsc", func(id string, service *config.NetworkService) *connection.Manager {
+		return connection.NewManager(&connectClient{}, id, service.URL, connection.WithEventBroker(broker))
+	}, 1)
+	require.NoError(t, pool.Update(ctx, []*config.NetworkService{{URL: u}}))
+
+	require.Equal(t, 0, oneShot(ctx, pool, func() {
+		cancelEvents()
+		drainEvents(ctx, eventsDone)
+	}))
+
+	// The up hook and the status file are done before the exit, the connection is left open
+	hookOut, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "up\n", string(hookOut))
+	status, err := os.ReadFile(c.StatusFile)
+	require.NoError(t, err)
+	require.Contains(t, string(status), `"id": "nsc-0"`)
+}